@@ -0,0 +1,9 @@
+# An Auth0 Action loaded using its name.
+data "auth0_action" "some-action-by-name" {
+  name = "my-action"
+}
+
+# An Auth0 Action loaded using its ID.
+data "auth0_action" "some-action-by-id" {
+  action_id = "abcdefghkijklmnopqrstuvwxyz0123456789"
+}
@@ -0,0 +1,8 @@
+# All Auth0 SAML Connections, loaded by filtering on their strategy.
+data "auth0_connections" "saml" {
+  strategy = "samlp"
+}
+
+output "saml_connection_ids" {
+  value = data.auth0_connections.saml.connections[*].id
+}
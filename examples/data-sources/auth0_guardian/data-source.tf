@@ -0,0 +1 @@
+data "auth0_guardian" "my_guardian" {}
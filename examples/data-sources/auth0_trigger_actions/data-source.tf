@@ -0,0 +1,4 @@
+# The actions bound to the post-login trigger, in the order in which they will be executed.
+data "auth0_trigger_actions" "login_flow" {
+  trigger = "post-login"
+}
@@ -1,4 +1,5 @@
-# Connections can be imported using their ID.
+# Connections can be imported using their ID or their name.
 #
 # Example:
 terraform import auth0_connection.google con_a17f21fdb24d48a0
+terraform import auth0_connection.google google-oauth2
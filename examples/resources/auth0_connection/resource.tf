@@ -17,6 +17,11 @@ resource "auth0_connection" "my_connection" {
     requires_username              = true
     disable_signup                 = false
     custom_scripts = {
+      login = <<EOF
+        function login(email, password, callback) {
+          return callback(new Error("Whoops!"));
+        }
+      EOF
       get_user = <<EOF
         function getByEmail(email, callback) {
           return callback(new Error("Whoops!"));
@@ -0,0 +1,5 @@
+# This resource can be imported by specifying the Connection ID,
+# which imports all the clients enabled on the connection at once.
+#
+# Example:
+terraform import auth0_connection_clients.my_conn_clients_assoc con_XXXXX
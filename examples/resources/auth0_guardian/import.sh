@@ -3,5 +3,9 @@
 #
 # We recommend [Version 4 UUID](https://www.uuidgenerator.net/version4)
 #
+# Importing reads the settings of every MFA factor. Secrets that can't be read
+# back, like the Amazon SNS `aws_secret_access_key`, are left empty in the state
+# and are set again on the next apply.
+#
 # Example:
 terraform import auth0_guardian.my_guardian 24940d4b-4bd4-44e7-894e-f92e4de36a40
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"gopkg.in/dnaeon/go-vcr.v3/recorder"
 
+	internalDebug "github.com/auth0/terraform-provider-auth0/internal/debug"
 	"github.com/auth0/terraform-provider-auth0/internal/provider"
 )
 
@@ -62,10 +63,14 @@ func configureTestProviderWithHTTPRecordings(
 		domain := data.Get("domain").(string)
 		debug := data.Get("debug").(bool)
 
+		httpClient := httpRecorder.GetDefaultClient()
+		if debug {
+			httpClient.Transport = internalDebug.NewTransport(httpClient.Transport)
+		}
+
 		clientOptions := []management.Option{
 			management.WithStaticToken("insecure"),
-			management.WithClient(httpRecorder.GetDefaultClient()),
-			management.WithDebug(debug),
+			management.WithClient(httpClient),
 		}
 
 		if domain != RecordingsDomain {
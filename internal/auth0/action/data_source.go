@@ -0,0 +1,117 @@
+package action
+
+import (
+	"context"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalSchema "github.com/auth0/terraform-provider-auth0/internal/schema"
+)
+
+// NewDataSource will return a new auth0_action data source.
+func NewDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readActionForDataSource,
+		Description: "Data source to retrieve a specific Auth0 action by `action_id` or `name`.",
+		Schema:      dataSourceSchema(),
+	}
+}
+
+func dataSourceSchema() map[string]*schema.Schema {
+	dataSourceSchema := internalSchema.TransformResourceToDataSource(NewResource().Schema)
+
+	// Secret values are never returned by the API
+	// and deploy only makes sense when managing the action.
+	delete(dataSourceSchema, "secrets")
+	delete(dataSourceSchema, "deploy")
+
+	dataSourceSchema["action_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The ID of the action. If not provided, `name` must be set.",
+		AtLeastOneOf: []string{"action_id", "name"},
+	}
+	dataSourceSchema["deployed"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether all the changes made to the action have been deployed.",
+	}
+
+	internalSchema.SetExistingAttributesAsOptional(dataSourceSchema, "name")
+	dataSourceSchema["name"].Description = "The name of the action. If not provided, `action_id` must be set."
+	dataSourceSchema["name"].AtLeastOneOf = []string{"action_id", "name"}
+
+	return dataSourceSchema
+}
+
+func readActionForDataSource(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	actionID := data.Get("action_id").(string)
+	if actionID == "" {
+		var diagnostics diag.Diagnostics
+		actionID, diagnostics = findActionIDByName(ctx, api, data.Get("name").(string))
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	action, err := api.Action.Read(actionID, management.Context(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(action.GetID())
+
+	result := multierror.Append(
+		flattenAction(data, action),
+		data.Set("deployed", action.AllChangesDeployed),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func findActionIDByName(ctx context.Context, api *management.Management, name string) (string, diag.Diagnostics) {
+	var actionIDs []string
+
+	page := 0
+	for {
+		actions, err := api.Action.List(
+			management.Page(page),
+			management.Parameter("actionName", name),
+			management.Context(ctx),
+		)
+		if err != nil {
+			return "", diag.FromErr(err)
+		}
+
+		for _, action := range actions.Actions {
+			if action.GetName() == name {
+				actionIDs = append(actionIDs, action.GetID())
+			}
+		}
+
+		if !actions.HasNext() {
+			break
+		}
+
+		page++
+	}
+
+	switch len(actionIDs) {
+	case 0:
+		return "", diag.Errorf("No action found with \"name\" = %q", name)
+	case 1:
+		return actionIDs[0], nil
+	default:
+		return "", diag.Errorf(
+			"Found %d actions with \"name\" = %q: %v. Use \"action_id\" to select one of them.",
+			len(actionIDs),
+			name,
+			actionIDs,
+		)
+	}
+}
@@ -0,0 +1,119 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindActionIDByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/actions/actions", r.URL.Path)
+
+		var actions []map[string]interface{}
+		switch r.URL.Query().Get("actionName") {
+		case "unique":
+			actions = []map[string]interface{}{
+				{"id": "act_1", "name": "unique"},
+				{"id": "act_2", "name": "unique-but-longer"},
+			}
+		case "duplicated":
+			actions = []map[string]interface{}{
+				{"id": "act_3", "name": "duplicated"},
+				{"id": "act_4", "name": "duplicated"},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"actions":  actions,
+			"total":    len(actions),
+			"start":    0,
+			"limit":    50,
+			"per_page": 50,
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	t.Run("it finds the action matching the name exactly", func(t *testing.T) {
+		actionID, diagnostics := findActionIDByName(context.Background(), api, "unique")
+		require.False(t, diagnostics.HasError(), diagnostics)
+		assert.Equal(t, "act_1", actionID)
+	})
+
+	t.Run("it fails when no action matches the name", func(t *testing.T) {
+		_, diagnostics := findActionIDByName(context.Background(), api, "missing")
+		require.True(t, diagnostics.HasError())
+		assert.Equal(t, `No action found with "name" = "missing"`, diagnostics[0].Summary)
+	})
+
+	t.Run("it fails when several actions match the name", func(t *testing.T) {
+		_, diagnostics := findActionIDByName(context.Background(), api, "duplicated")
+		require.True(t, diagnostics.HasError())
+		assert.Equal(
+			t,
+			`Found 2 actions with "name" = "duplicated": [act_3 act_4]. Use "action_id" to select one of them.`,
+			diagnostics[0].Summary,
+		)
+	})
+}
+
+func TestReadActionForDataSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		var response interface{}
+		switch r.URL.Path {
+		case "/api/v2/actions/actions":
+			response = map[string]interface{}{
+				"actions":  []map[string]interface{}{{"id": "act_1", "name": "my-action"}},
+				"total":    1,
+				"start":    0,
+				"limit":    50,
+				"per_page": 50,
+			}
+		case "/api/v2/actions/actions/act_1":
+			response = map[string]interface{}{
+				"id":                   "act_1",
+				"name":                 "my-action",
+				"runtime":              "node18",
+				"code":                 "exports.onExecutePostLogin = async (event, api) => {};",
+				"all_changes_deployed": true,
+				"supported_triggers":   []map[string]interface{}{{"id": "post-login", "version": "v3"}},
+			}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	data := schema.TestResourceDataRaw(t, NewDataSource().Schema, map[string]interface{}{
+		"name": "my-action",
+	})
+
+	diagnostics := readActionForDataSource(context.Background(), data, api)
+	require.False(t, diagnostics.HasError(), diagnostics)
+
+	assert.Equal(t, "act_1", data.Id())
+	assert.Equal(t, "node18", data.Get("runtime"))
+	assert.Equal(t, true, data.Get("deployed"))
+	assert.Equal(t, "post-login", data.Get("supported_triggers.0.id"))
+	assert.Equal(t, "v3", data.Get("supported_triggers.0.version"))
+}
@@ -0,0 +1,22 @@
+package action_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/auth0/terraform-provider-auth0/internal/acctest"
+)
+
+func TestAccDataSourceActionRequiredArguments(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "auth0_action" "test" { }`,
+				ExpectError: regexp.MustCompile("one of `action_id,name` must be specified"),
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package action
+
+import (
+	"context"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NewTriggerActionsDataSource will return a new auth0_trigger_actions data source.
+func NewTriggerActionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readTriggerActionsForDataSource,
+		Description: "Data source to retrieve the actions bound to a trigger, " +
+			"in the order in which they will be executed.",
+		Schema: map[string]*schema.Schema{
+			"trigger": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(Triggers, false),
+				Description:  "The ID of the trigger to retrieve the bound actions for.",
+			},
+			"actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Action ID.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of an action.",
+						},
+					},
+				},
+				Description: "The actions bound to this trigger, in the order in which they will be executed.",
+			},
+		},
+	}
+}
+
+func readTriggerActionsForDataSource(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	trigger := data.Get("trigger").(string)
+	triggerBindings, err := api.Action.Bindings(trigger, management.Context(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(trigger)
+
+	return diag.FromErr(data.Set("actions", flattenTriggerBindingActions(triggerBindings.Bindings)))
+}
@@ -0,0 +1,47 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTriggerActionsForDataSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v2/actions/triggers/post-login/bindings", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"bindings": []map[string]interface{}{
+				{"display_name": "Foo", "action": map[string]interface{}{"id": "action_foo"}},
+				{"display_name": "Bar", "action": map[string]interface{}{"id": "action_bar"}},
+			},
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	data := schema.TestResourceDataRaw(t, NewTriggerActionsDataSource().Schema, map[string]interface{}{
+		"trigger": "post-login",
+	})
+
+	diagnostics := readTriggerActionsForDataSource(context.Background(), data, api)
+	require.False(t, diagnostics.HasError(), diagnostics)
+
+	assert.Equal(t, "post-login", data.Id())
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "action_foo", "display_name": "Foo"},
+		map[string]interface{}{"id": "action_bar", "display_name": "Bar"},
+	}, data.Get("actions"))
+}
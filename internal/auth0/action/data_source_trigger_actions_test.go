@@ -0,0 +1,22 @@
+package action_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/auth0/terraform-provider-auth0/internal/acctest"
+)
+
+func TestAccDataSourceTriggerActionsInvalidTrigger(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "auth0_trigger_actions" "test" { trigger = "not-a-trigger" }`,
+				ExpectError: regexp.MustCompile(`expected trigger to be one of`),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package action
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
@@ -96,6 +97,46 @@ func expandTriggerBindings(config cty.Value) []*management.ActionBinding {
 	return triggerBindings
 }
 
+func validateTriggerBindings(config cty.Value) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	seenActionIDs := make(map[string]bool)
+	index := 0
+
+	config.ForEachElement(func(_ cty.Value, action cty.Value) (stop bool) {
+		actionPath := cty.Path{cty.GetAttrStep{Name: "actions"}, cty.IndexStep{Key: cty.NumberIntVal(int64(index))}}
+		index++
+
+		if actionID := action.GetAttr("id"); actionID.IsKnown() && !actionID.IsNull() {
+			if seenActionIDs[actionID.AsString()] {
+				diagnostics = append(diagnostics, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Duplicate Action In Trigger Binding",
+					Detail: fmt.Sprintf("The action with ID %q is bound more than once to this trigger. "+
+						"Each action can only be bound once.",
+						actionID.AsString(),
+					),
+					AttributePath: actionPath.GetAttr("id"),
+				})
+			}
+			seenActionIDs[actionID.AsString()] = true
+		}
+
+		if displayName := action.GetAttr("display_name"); displayName.IsKnown() &&
+			(displayName.IsNull() || strings.TrimSpace(displayName.AsString()) == "") {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Empty Action Display Name",
+				Detail:        "The display name of an action bound to a trigger cannot be empty.",
+				AttributePath: actionPath.GetAttr("display_name"),
+			})
+		}
+
+		return stop
+	})
+
+	return diagnostics
+}
+
 func preventErasingUnmanagedSecrets(d *schema.ResourceData, api *management.Management) diag.Diagnostics {
 	if !d.HasChange("secrets") {
 		return nil
@@ -76,3 +76,75 @@ func TestCheckForUntrackedActionSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTriggerBindings(t *testing.T) {
+	newAction := func(id, displayName string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"id":           cty.StringVal(id),
+			"display_name": cty.StringVal(displayName),
+		})
+	}
+
+	var testCases = []struct {
+		name                string
+		givenActions        cty.Value
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name: "actions are valid",
+			givenActions: cty.ListVal([]cty.Value{
+				newAction("action-1", "Action 1"),
+				newAction("action-2", "Action 2"),
+			}),
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "action is bound more than once",
+			givenActions: cty.ListVal([]cty.Value{
+				newAction("action-1", "Action 1"),
+				newAction("action-2", "Action 2"),
+				newAction("action-1", "Action 1 Again"),
+			}),
+			expectedDiagnostics: diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "Duplicate Action In Trigger Binding",
+					Detail: `The action with ID "action-1" is bound more than once to this trigger. ` +
+						"Each action can only be bound once.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "actions"},
+						cty.IndexStep{Key: cty.NumberIntVal(2)},
+						cty.GetAttrStep{Name: "id"},
+					},
+				},
+			},
+		},
+		{
+			name: "action has an empty display name",
+			givenActions: cty.ListVal([]cty.Value{
+				newAction("action-1", "Action 1"),
+				newAction("action-2", "  "),
+			}),
+			expectedDiagnostics: diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "Empty Action Display Name",
+					Detail:   "The display name of an action bound to a trigger cannot be empty.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "actions"},
+						cty.IndexStep{Key: cty.NumberIntVal(1)},
+						cty.GetAttrStep{Name: "display_name"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := validateTriggerBindings(testCase.givenActions)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
@@ -2,8 +2,26 @@ package action
 
 import (
 	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func flattenAction(d *schema.ResourceData, action *management.Action) error {
+	result := multierror.Append(
+		d.Set("name", action.Name),
+		d.Set("supported_triggers", flattenActionTriggers(action.SupportedTriggers)),
+		d.Set("code", action.Code),
+		d.Set("dependencies", flattenActionDependencies(action.GetDependencies())),
+		d.Set("runtime", action.Runtime),
+	)
+
+	if action.DeployedVersion != nil {
+		result = multierror.Append(result, d.Set("version_id", action.DeployedVersion.GetID()))
+	}
+
+	return result.ErrorOrNil()
+}
+
 func flattenActionTriggers(triggers []management.ActionTrigger) []interface{} {
 	var result []interface{}
 
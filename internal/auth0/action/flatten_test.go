@@ -0,0 +1,75 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenTriggerBindingActionsPreservesOrder(t *testing.T) {
+	bindings := []*management.ActionBinding{
+		{
+			DisplayName: auth0.String("Bar"),
+			Action:      &management.Action{ID: auth0.String("action_bar")},
+		},
+		{
+			DisplayName: auth0.String("Foo"),
+			Action:      &management.Action{ID: auth0.String("action_foo")},
+		},
+	}
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "action_bar", "display_name": "Bar"},
+		map[string]interface{}{"id": "action_foo", "display_name": "Foo"},
+	}, flattenTriggerBindingActions(bindings))
+}
+
+func TestReadTriggerBindingDetectsActionsReorderedOutOfBand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v2/actions/triggers/post-login/bindings", r.URL.Path)
+
+		// The bindings were reordered in the dashboard.
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"bindings": []map[string]interface{}{
+				{"display_name": "Bar", "action": map[string]interface{}{"id": "action_bar"}},
+				{"display_name": "Foo", "action": map[string]interface{}{"id": "action_foo"}},
+			},
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	configuredActions := []interface{}{
+		map[string]interface{}{"id": "action_foo", "display_name": "Foo"},
+		map[string]interface{}{"id": "action_bar", "display_name": "Bar"},
+	}
+
+	data := schema.TestResourceDataRaw(t, NewTriggerBindingResource().Schema, map[string]interface{}{
+		"trigger": "post-login",
+		"actions": configuredActions,
+	})
+	data.SetId("post-login")
+
+	diagnostics := readTriggerBinding(context.Background(), data, api)
+	require.False(t, diagnostics.HasError(), diagnostics)
+
+	actions := data.Get("actions").([]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "action_bar", "display_name": "Bar"},
+		map[string]interface{}{"id": "action_foo", "display_name": "Foo"},
+	}, actions)
+	assert.NotEqual(t, configuredActions, actions, "the reordering must surface as a diff against the config")
+}
@@ -3,14 +3,14 @@ package action
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
 // NewResource will return a new auth0_action resource.
@@ -152,28 +152,14 @@ func readAction(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 
 	action, err := api.Action.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
 
-	result := multierror.Append(
-		d.Set("name", action.Name),
-		d.Set("supported_triggers", flattenActionTriggers(action.SupportedTriggers)),
-		d.Set("code", action.Code),
-		d.Set("dependencies", flattenActionDependencies(action.GetDependencies())),
-		d.Set("runtime", action.Runtime),
-	)
-
-	if action.DeployedVersion != nil {
-		result = multierror.Append(result, d.Set("version_id", action.DeployedVersion.GetID()))
-	}
-
-	return diag.FromErr(result.ErrorOrNil())
+	return diag.FromErr(flattenAction(d, action))
 }
 
 func updateAction(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -200,7 +186,7 @@ func deleteAction(ctx context.Context, d *schema.ResourceData, m interface{}) di
 	api := m.(*management.Management)
 
 	if err := api.Action.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
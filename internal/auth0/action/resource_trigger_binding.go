@@ -2,14 +2,35 @@ package action
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"strings"
 
 	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
+// Triggers holds the IDs of the triggers to which actions can be bound.
+var Triggers = []string{
+	"post-login",
+	"credentials-exchange",
+	"pre-user-registration",
+	"post-user-registration",
+	"post-change-password",
+	"send-phone-message",
+	"password-reset-post-challenge",
+	"custom-email-provider",
+	"custom-phone-provider",
+	"iga-approval",
+	"iga-certification",
+	"iga-fulfillment-assignment",
+	"iga-fulfillment-execution",
+}
+
 // NewTriggerBindingResource will return a new auth0_trigger_binding resource.
 func NewTriggerBindingResource() *schema.Resource {
 	return &schema.Resource{
@@ -26,22 +47,11 @@ func NewTriggerBindingResource() *schema.Resource {
 			"the appropriate flow.",
 		Schema: map[string]*schema.Schema{
 			"trigger": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"post-login",
-					"credentials-exchange",
-					"pre-user-registration",
-					"post-user-registration",
-					"post-change-password",
-					"send-phone-message",
-					"iga-approval",
-					"iga-certification",
-					"iga-fulfillment-assignment",
-					"iga-fulfillment-execution",
-				}, false),
-				Description: "The ID of the trigger to bind with.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(Triggers, false),
+				Description:  "The ID of the trigger to bind with.",
 			},
 			"actions": {
 				Type:     schema.TypeList,
@@ -62,14 +72,32 @@ func NewTriggerBindingResource() *schema.Resource {
 				},
 				Description: "The actions bound to this trigger",
 			},
+			"skip_trigger_compatibility_check": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "By default, each bound action is fetched before applying the changes to check " +
+					"that it supports the trigger, so that a mismatch is reported with the name of the " +
+					"incompatible action. Set this to `true` to skip that check and save the extra API calls.",
+			},
 		},
 	}
 }
 
 func createTriggerBinding(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("trigger").(string)
-	triggerBindings := expandTriggerBindings(d.GetRawConfig().GetAttr("actions"))
+	actions := d.GetRawConfig().GetAttr("actions")
+	if diagnostics := validateTriggerBindings(actions); diagnostics.HasError() {
+		return diagnostics
+	}
+
 	api := m.(*management.Management)
+	if !d.Get("skip_trigger_compatibility_check").(bool) {
+		if diagnostics := checkActionsSupportTrigger(ctx, api, id, actions); diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	triggerBindings := expandTriggerBindings(actions)
 	if err := api.Action.UpdateBindings(id, triggerBindings); err != nil {
 		return diag.FromErr(err)
 	}
@@ -83,11 +111,9 @@ func readTriggerBinding(ctx context.Context, d *schema.ResourceData, m interface
 	api := m.(*management.Management)
 	triggerBindings, err := api.Action.Bindings(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -96,8 +122,19 @@ func readTriggerBinding(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func updateTriggerBinding(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	triggerBindings := expandTriggerBindings(d.GetRawConfig().GetAttr("actions"))
+	actions := d.GetRawConfig().GetAttr("actions")
+	if diagnostics := validateTriggerBindings(actions); diagnostics.HasError() {
+		return diagnostics
+	}
+
 	api := m.(*management.Management)
+	if d.HasChange("actions") && !d.Get("skip_trigger_compatibility_check").(bool) {
+		if diagnostics := checkActionsSupportTrigger(ctx, api, d.Id(), actions); diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	triggerBindings := expandTriggerBindings(actions)
 	if err := api.Action.UpdateBindings(d.Id(), triggerBindings); err != nil {
 		return diag.FromErr(err)
 	}
@@ -108,14 +145,76 @@ func updateTriggerBinding(ctx context.Context, d *schema.ResourceData, m interfa
 func deleteTriggerBinding(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 	if err := api.Action.UpdateBindings(d.Id(), []*management.ActionBinding{}); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
+
+// checkActionsSupportTrigger fetches every action about to be bound and
+// reports the ones that don't support the trigger, as the API otherwise
+// rejects the whole binding with an error that doesn't name the action.
+func checkActionsSupportTrigger(
+	ctx context.Context,
+	api *management.Management,
+	trigger string,
+	actions cty.Value,
+) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	index := 0
+
+	actions.ForEachElement(func(_ cty.Value, action cty.Value) (stop bool) {
+		actionPath := cty.Path{cty.GetAttrStep{Name: "actions"}, cty.IndexStep{Key: cty.NumberIntVal(int64(index))}}
+		index++
+
+		actionID := action.GetAttr("id")
+		if !actionID.IsKnown() || actionID.IsNull() {
+			return stop
+		}
+
+		boundAction, err := api.Action.Read(actionID.AsString(), management.Context(ctx))
+		if err != nil {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Failed To Read Bound Action",
+				Detail:        fmt.Sprintf("Failed to read the action with ID %q: %s", actionID.AsString(), err),
+				AttributePath: actionPath.GetAttr("id"),
+			})
+			return stop
+		}
+
+		supportedTriggers := make([]string, 0, len(boundAction.SupportedTriggers))
+		for _, supportedTrigger := range boundAction.SupportedTriggers {
+			if supportedTrigger.GetID() == trigger {
+				return stop
+			}
+			supportedTriggers = append(supportedTriggers, supportedTrigger.GetID())
+		}
+
+		supportedTriggersDetail := "it doesn't support any trigger."
+		if len(supportedTriggers) > 0 {
+			supportedTriggersDetail = fmt.Sprintf("it only supports: %s.", strings.Join(supportedTriggers, ", "))
+		}
+
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Action Does Not Support Trigger",
+			Detail: fmt.Sprintf(
+				"The action %q (%s) can't be bound to the %q trigger, %s",
+				boundAction.GetName(),
+				actionID.AsString(),
+				trigger,
+				supportedTriggersDetail,
+			),
+			AttributePath: actionPath.GetAttr("id"),
+		})
+
+		return stop
+	})
+
+	return diagnostics
+}
@@ -0,0 +1,180 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckActionsSupportTrigger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		actions := map[string]map[string]interface{}{
+			"act_login": {
+				"id":                 "act_login",
+				"name":               "Login Action",
+				"supported_triggers": []map[string]interface{}{{"id": "post-login", "version": "v3"}},
+			},
+			"act_registration": {
+				"id":                 "act_registration",
+				"name":               "Registration Action",
+				"supported_triggers": []map[string]interface{}{{"id": "pre-user-registration", "version": "v2"}},
+			},
+			"act_draft": {
+				"id":                 "act_draft",
+				"name":               "Draft Action",
+				"supported_triggers": []map[string]interface{}{},
+			},
+		}
+
+		action, ok := actions[strings.TrimPrefix(r.URL.Path, "/api/v2/actions/actions/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(action)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	givenActions := func(actionIDs ...string) cty.Value {
+		var actions []cty.Value
+		for _, actionID := range actionIDs {
+			actions = append(actions, cty.ObjectVal(map[string]cty.Value{
+				"id":           cty.StringVal(actionID),
+				"display_name": cty.StringVal(actionID),
+			}))
+		}
+		return cty.ListVal(actions)
+	}
+
+	t.Run("it accepts actions supporting the trigger", func(t *testing.T) {
+		diagnostics := checkActionsSupportTrigger(context.Background(), api, "post-login", givenActions("act_login"))
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("it names the actions not supporting the trigger", func(t *testing.T) {
+		diagnostics := checkActionsSupportTrigger(
+			context.Background(),
+			api,
+			"post-login",
+			givenActions("act_login", "act_registration"),
+		)
+
+		assert.Equal(t, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Action Does Not Support Trigger",
+				Detail: `The action "Registration Action" (act_registration) can't be bound to the ` +
+					`"post-login" trigger, it only supports: pre-user-registration.`,
+				AttributePath: cty.Path{
+					cty.GetAttrStep{Name: "actions"},
+					cty.IndexStep{Key: cty.NumberIntVal(1)},
+					cty.GetAttrStep{Name: "id"},
+				},
+			},
+		}, diagnostics)
+	})
+
+	t.Run("it reports the actions not supporting any trigger", func(t *testing.T) {
+		diagnostics := checkActionsSupportTrigger(context.Background(), api, "post-login", givenActions("act_draft"))
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(
+			t,
+			`The action "Draft Action" (act_draft) can't be bound to the "post-login" trigger, `+
+				`it doesn't support any trigger.`,
+			diagnostics[0].Detail,
+		)
+	})
+
+	t.Run("it reports the actions that can't be read", func(t *testing.T) {
+		diagnostics := checkActionsSupportTrigger(context.Background(), api, "post-login", givenActions("act_missing"))
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "Failed To Read Bound Action", diagnostics[0].Summary)
+		assert.Contains(t, diagnostics[0].Detail, `Failed to read the action with ID "act_missing"`)
+	})
+}
+
+func TestTriggerBindingTriggerValidation(t *testing.T) {
+	validateTrigger := NewTriggerBindingResource().Schema["trigger"].ValidateFunc
+
+	for _, trigger := range []string{"password-reset-post-challenge", "custom-email-provider", "custom-phone-provider"} {
+		_, errs := validateTrigger(trigger, "trigger")
+		assert.Empty(t, errs, "Expected %q to be a valid trigger", trigger)
+	}
+
+	_, errs := validateTrigger("unknown-trigger", "trigger")
+	assert.NotEmpty(t, errs)
+}
+
+func TestUpdateTriggerBindingOnlyChecksChangedActions(t *testing.T) {
+	actionReads := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v2/actions/actions/") {
+			actionReads++
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		assert.Equal(t, "/api/v2/actions/triggers/post-login/bindings", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"bindings":[{"display_name":"Login Action","action":{"id":"act_login"}}]}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	resource := NewTriggerBindingResource()
+	actions := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"id":           cty.StringVal("act_login"),
+			"display_name": cty.StringVal("Login Action"),
+		}),
+	})
+
+	data := resource.Data(&terraform.InstanceState{
+		ID: "post-login",
+		Attributes: map[string]string{
+			"id":                               "post-login",
+			"trigger":                          "post-login",
+			"actions.#":                        "1",
+			"actions.0.id":                     "act_login",
+			"actions.0.display_name":           "Login Action",
+			"skip_trigger_compatibility_check": "true",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"id":                               cty.NullVal(cty.String),
+			"trigger":                          cty.StringVal("post-login"),
+			"actions":                          actions,
+			"skip_trigger_compatibility_check": cty.False,
+		}),
+	})
+	require.NoError(t, data.Set("skip_trigger_compatibility_check", false))
+
+	diagnostics := updateTriggerBinding(context.Background(), data, api)
+
+	assert.False(t, diagnostics.HasError(), "Expected no errors, got %v", diagnostics)
+	assert.Equal(t, 0, actionReads)
+}
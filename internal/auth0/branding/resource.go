@@ -3,7 +3,6 @@ package branding
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
@@ -12,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -260,7 +260,7 @@ func flattenBrandingColors(brandingColors *management.BrandingColors) []interfac
 func flattenBrandingUniversalLogin(api *management.Management) ([]interface{}, error) {
 	universalLogin, err := api.Branding.UniversalLogin()
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			return nil, nil
 		}
 		return nil, err
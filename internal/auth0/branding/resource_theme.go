@@ -2,7 +2,6 @@ package branding
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -10,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -484,7 +484,7 @@ func readBrandingTheme(ctx context.Context, data *schema.ResourceData, meta inte
 
 	brandingTheme, err := api.BrandingTheme.Default()
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			data.SetId("")
 			return nil
 		}
@@ -521,11 +521,9 @@ func deleteBrandingTheme(ctx context.Context, data *schema.ResourceData, meta in
 	api := meta.(*management.Management)
 
 	if err := api.BrandingTheme.Delete(data.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				data.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			data.SetId("")
+			return nil
 		}
 
 		return diag.FromErr(err)
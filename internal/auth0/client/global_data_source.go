@@ -21,6 +21,21 @@ func NewGlobalDataSource() *schema.Resource {
 func globalDataSourceSchema() map[string]*schema.Schema {
 	dataSourceSchema := internalSchema.TransformResourceToDataSource(NewResource().Schema)
 	delete(dataSourceSchema, "client_secret_rotation_trigger")
+
+	// The global client secret grants access to the whole tenant,
+	// so we only store it in the state when explicitly requested.
+	dataSourceSchema["include_secret"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Whether to retrieve the `client_secret` of the global client. " +
+			"Defaults to `false`, to prevent the secret from being stored in the state.",
+	}
+	dataSourceSchema["client_secret"].Sensitive = true
+	dataSourceSchema["client_secret"].Description = "Secret for the global client. " +
+		"Only retrieved when `include_secret` is set to `true`, otherwise it will contain an empty string. " +
+		"To access this attribute you need to add the `read:client_keys` scope to the Terraform client."
+
 	return dataSourceSchema
 }
 
@@ -28,5 +43,11 @@ func readDataGlobalClient(ctx context.Context, d *schema.ResourceData, m interfa
 	if err := readGlobalClientID(ctx, d, m); err != nil {
 		return err
 	}
-	return readClient(ctx, d, m)
+
+	diagnostics := readClient(ctx, d, m)
+	if diagnostics.HasError() || d.Get("include_secret").(bool) {
+		return diagnostics
+	}
+
+	return append(diagnostics, diag.FromErr(d.Set("client_secret", ""))...)
 }
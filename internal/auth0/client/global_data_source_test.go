@@ -29,6 +29,8 @@ func TestAccDataGlobalClient(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.auth0_global_client.global", "client_id"),
 					resource.TestCheckResourceAttr("data.auth0_global_client.global", "app_type", ""),
 					resource.TestCheckResourceAttr("data.auth0_global_client.global", "name", "All Applications"),
+					resource.TestCheckResourceAttr("data.auth0_global_client.global", "include_secret", "false"),
+					resource.TestCheckResourceAttr("data.auth0_global_client.global", "client_secret", ""),
 				),
 			},
 		},
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -10,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	internalValidation "github.com/auth0/terraform-provider-auth0/internal/validation"
 )
 
@@ -751,7 +751,7 @@ func readClient(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 
 	client, err := api.Client.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -822,7 +822,7 @@ func deleteClient(ctx context.Context, d *schema.ResourceData, m interface{}) di
 	api := m.(*management.Management)
 
 	if err := api.Client.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -2,13 +2,13 @@ package client
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -80,7 +80,7 @@ func readClientGrant(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	clientGrant, err := api.ClientGrant.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -113,7 +113,7 @@ func deleteClientGrant(ctx context.Context, d *schema.ResourceData, m interface{
 	api := m.(*management.Management)
 
 	if err := api.ClientGrant.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -14,13 +14,20 @@ import (
 func NewDataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: readConnectionForDataSource,
-		Description: "Data source to retrieve a specific Auth0 connection by `connection_id` or `name`.",
-		Schema:      dataSourceSchema(),
+		Description: "Data source to retrieve a specific Auth0 connection by `connection_id` or `name`. " +
+			"Secrets within `options`, such as the `client_secret` or the `signing_key`, are not exposed.",
+		Schema: dataSourceSchema(),
 	}
 }
 
 func dataSourceSchema() map[string]*schema.Schema {
 	dataSourceSchema := internalSchema.TransformResourceToDataSource(NewResource().Schema)
+
+	// Only relevant when managing the connection.
+	delete(dataSourceSchema, "validate_idp_initiated_client_id")
+	delete(dataSourceSchema, "validate_realms_uniqueness")
+	delete(dataSourceSchema, "validate_display_name_uniqueness")
+
 	dataSourceSchema["connection_id"] = &schema.Schema{
 		Type:         schema.TypeString,
 		Optional:     true,
@@ -39,34 +46,68 @@ func readConnectionForDataSource(ctx context.Context, data *schema.ResourceData,
 	connectionID := data.Get("connection_id").(string)
 	if connectionID != "" {
 		data.SetId(connectionID)
-		return readConnection(ctx, data, meta)
+		return readConnectionWithoutSecrets(ctx, data, meta)
 	}
 
 	api := meta.(*management.Management)
 	name := data.Get("name").(string)
-	page := 0
-	for {
-		connections, err := api.Connection.List(
-			management.IncludeFields("id", "name"),
-			management.Page(page),
-		)
-		if err != nil {
-			return diag.FromErr(err)
+
+	connections, err := listAllConnections(
+		ctx,
+		api,
+		management.IncludeFields("id", "name", "strategy"),
+		management.Parameter("name", name),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, connection := range connections {
+		if connection.GetName() == name {
+			data.SetId(connection.GetID())
+			return readConnectionWithoutSecrets(ctx, data, meta)
 		}
+	}
 
-		for _, connection := range connections.Connections {
-			if connection.GetName() == name {
-				data.SetId(connection.GetID())
-				return readConnection(ctx, data, meta)
-			}
+	return diag.Errorf("No connection found with \"name\" = %q", name)
+}
+
+func readConnectionWithoutSecrets(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := readConnection(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return diag.FromErr(clearOptionsSecrets(data))
+}
+
+// clearOptionsSecrets blanks the option secrets returned by the API, as
+// the data source schema can't mark nested attributes as sensitive.
+func clearOptionsSecrets(data *schema.ResourceData) error {
+	options := data.Get("options").([]interface{})
+	for _, connectionOptions := range options {
+		connectionOptions, ok := connectionOptions.(map[string]interface{})
+		if !ok {
+			continue
 		}
 
-		if !connections.HasNext() {
-			break
+		connectionOptions["client_secret"] = ""
+		connectionOptions["twilio_token"] = ""
+		connectionOptions["configuration"] = map[string]interface{}{}
+
+		gatewayAuthentication, _ := connectionOptions["gateway_authentication"].([]interface{})
+		for _, gatewaySettings := range gatewayAuthentication {
+			if gatewaySettings, ok := gatewaySettings.(map[string]interface{}); ok {
+				gatewaySettings["secret"] = ""
+			}
 		}
 
-		page++
+		signingKey, _ := connectionOptions["signing_key"].([]interface{})
+		for _, keySettings := range signingKey {
+			if keySettings, ok := keySettings.(map[string]interface{}); ok {
+				keySettings["key"] = ""
+			}
+		}
 	}
 
-	return diag.Errorf("No connection found with \"name\" = %q", name)
+	return data.Set("options", options)
 }
@@ -0,0 +1,69 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewConnectionsDataSource will return a new auth0_connections data source.
+func NewConnectionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readConnectionsForDataSource,
+		Description: "Data source to retrieve all Auth0 connections, optionally filtered by `strategy`.",
+		Schema: map[string]*schema.Schema{
+			"strategy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only retrieve the connections of this strategy, for example `samlp`.",
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the connection.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the connection.",
+						},
+						"strategy": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The strategy of the connection.",
+						},
+					},
+				},
+				Description: "The connections matching the `strategy` filter, or all connections if it isn't set.",
+			},
+		},
+	}
+}
+
+func readConnectionsForDataSource(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	options := []management.RequestOption{
+		management.IncludeFields("id", "name", "strategy"),
+	}
+	if strategy := data.Get("strategy").(string); strategy != "" {
+		options = append(options, management.Parameter("strategy", strategy))
+	}
+
+	connections, err := listAllConnections(ctx, api, options...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(resource.UniqueId())
+
+	return diag.FromErr(data.Set("connections", flattenConnectionsForDataSource(connections)))
+}
@@ -0,0 +1,35 @@
+package connection
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConnectionsForDataSource(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections", r.URL.Path)
+		assert.Equal(t, "google-oauth2", r.URL.Query().Get("strategy"))
+		assert.Equal(t, "id,name,strategy", r.URL.Query().Get("fields"))
+
+		writeStubConnectionList(t, w, []map[string]string{
+			{"id": "con_1", "name": "google", "strategy": "google-oauth2"},
+			{"id": "con_2", "name": "google-staging", "strategy": "google-oauth2"},
+		})
+	})
+
+	data := NewConnectionsDataSource().TestResourceData()
+	require.NoError(t, data.Set("strategy", "google-oauth2"))
+
+	diagnostics := readConnectionsForDataSource(context.Background(), data, api)
+	require.False(t, diagnostics.HasError(), "%v", diagnostics)
+
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "con_1", "name": "google", "strategy": "google-oauth2"},
+		map[string]interface{}{"id": "con_2", "name": "google-staging", "strategy": "google-oauth2"},
+	}, data.Get("connections"))
+}
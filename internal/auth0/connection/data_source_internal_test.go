@@ -0,0 +1,60 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearOptionsSecrets(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, dataSourceSchema(), nil)
+
+	require.NoError(t, data.Set("options", []interface{}{
+		map[string]interface{}{
+			"client_id":        "someClientID",
+			"client_secret":    "someSecret",
+			"twilio_sid":       "someSID",
+			"twilio_token":     "someToken",
+			"strategy_version": 2,
+			"configuration": map[string]interface{}{
+				"foo": "bar",
+			},
+			"gateway_authentication": []interface{}{
+				map[string]interface{}{
+					"method":   "bearer",
+					"audience": "https://gateway.example.com",
+					"secret":   "someGatewaySecret",
+				},
+			},
+			"signing_key": []interface{}{
+				map[string]interface{}{
+					"cert": "someCert",
+					"key":  "someKey",
+				},
+			},
+		},
+	}))
+
+	require.NoError(t, clearOptionsSecrets(data))
+
+	assert.Equal(t, "", data.Get("options.0.client_secret"))
+	assert.Equal(t, "someClientID", data.Get("options.0.client_id"))
+	assert.Equal(t, "", data.Get("options.0.twilio_token"))
+	assert.Equal(t, "someSID", data.Get("options.0.twilio_sid"))
+	assert.Empty(t, data.Get("options.0.configuration"))
+	assert.Equal(t, "", data.Get("options.0.gateway_authentication.0.secret"))
+	assert.Equal(t, "https://gateway.example.com", data.Get("options.0.gateway_authentication.0.audience"))
+	assert.Equal(t, "", data.Get("options.0.signing_key.0.key"))
+	assert.Equal(t, "someCert", data.Get("options.0.signing_key.0.cert"))
+	assert.Equal(t, 2, data.Get("options.0.strategy_version"))
+}
+
+func TestClearOptionsSecretsWithoutOptions(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, dataSourceSchema(), nil)
+
+	require.NoError(t, clearOptionsSecrets(data))
+
+	assert.Empty(t, data.Get("options"))
+}
@@ -0,0 +1,110 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConnectionForDataSourceByName(t *testing.T) {
+	pages := [][]map[string]string{
+		{
+			{"id": "con_1", "name": "first-connection", "strategy": "auth0"},
+			{"id": "con_2", "name": "second-connection", "strategy": "auth0"},
+		},
+		{
+			{"id": "con_3", "name": "third-connection", "strategy": "auth0"},
+			{"id": "con_4", "name": "fourth-connection", "strategy": "auth0"},
+		},
+		{
+			{"id": "con_5", "name": "fifth-connection", "strategy": "auth0"},
+		},
+	}
+
+	var testCases = []struct {
+		testName          string
+		givenName         string
+		expectedID        string
+		expectedListCalls int
+		expectedError     string
+	}{
+		{
+			testName:          "it finds a connection on the first page",
+			givenName:         "second-connection",
+			expectedID:        "con_2",
+			expectedListCalls: 3,
+		},
+		{
+			testName:          "it finds a connection on a later page",
+			givenName:         "fifth-connection",
+			expectedID:        "con_5",
+			expectedListCalls: 3,
+		},
+		{
+			testName:          "it fails once all pages are exhausted",
+			givenName:         "missing-connection",
+			expectedListCalls: 3,
+			expectedError:     "No connection found with \"name\" = \"missing-connection\"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			listCalls := 0
+			api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.URL.Path != "/api/v2/connections" {
+					assert.Equal(t, "/api/v2/connections/"+testCase.expectedID, r.URL.Path)
+					err := json.NewEncoder(w).Encode(map[string]interface{}{
+						"id":       testCase.expectedID,
+						"name":     testCase.givenName,
+						"strategy": "auth0",
+					})
+					require.NoError(t, err)
+					return
+				}
+
+				listCalls++
+				assert.Equal(t, "id,name,strategy", r.URL.Query().Get("fields"))
+				assert.Equal(t, testCase.givenName, r.URL.Query().Get("name"))
+
+				page, err := strconv.Atoi(r.URL.Query().Get("page"))
+				require.NoError(t, err)
+				require.Less(t, page, len(pages))
+
+				err = json.NewEncoder(w).Encode(map[string]interface{}{
+					"start":       page * 2,
+					"limit":       2,
+					"length":      len(pages[page]),
+					"total":       5,
+					"connections": pages[page],
+				})
+				require.NoError(t, err)
+			})
+
+			data := schema.TestResourceDataRaw(t, dataSourceSchema(), map[string]interface{}{
+				"name": testCase.givenName,
+			})
+
+			diags := readConnectionForDataSource(context.Background(), data, api)
+			assert.Equal(t, testCase.expectedListCalls, listCalls)
+
+			if testCase.expectedError != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, testCase.expectedError, diags[0].Summary)
+				return
+			}
+
+			require.False(t, diags.HasError())
+			assert.Equal(t, testCase.expectedID, data.Id())
+			assert.Equal(t, testCase.givenName, data.Get("name"))
+		})
+	}
+}
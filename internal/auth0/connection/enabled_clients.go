@@ -0,0 +1,201 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
+	"github.com/auth0/terraform-provider-auth0/internal/value"
+)
+
+// maxEnabledClientsUpdateAttempts is the number of times we try to apply
+// a change to the enabled clients of a connection before giving up.
+const maxEnabledClientsUpdateAttempts = 5
+
+// enabledClientsUpdateBackoff is the base delay before retrying a change to the
+// enabled clients of a connection. It doubles on every attempt and is jittered,
+// so that concurrent processes retrying on the same connection spread out.
+var enabledClientsUpdateBackoff = 200 * time.Millisecond
+
+// updateEnabledClients enables or disables clients on a connection.
+//
+// The enabled clients can only be updated as a whole, so other processes
+// managing the same connection concurrently can overwrite our change. To
+// account for that, the change is retried whenever the API responds with a
+// conflict or when the change is not reflected on the connection afterwards.
+//
+// The go-auth0 SDK doesn't expose the PATCH /connections/{id}/clients
+// endpoint, which would add or remove a single client, so we fall back to
+// this read-modify-write loop.
+func updateEnabledClients(
+	ctx context.Context,
+	api *management.Management,
+	connectionID string,
+	clientIDs []string,
+	enable bool,
+) error {
+	var err error
+	for attempt := 1; attempt <= maxEnabledClientsUpdateAttempts; attempt++ {
+		if attempt > 1 {
+			if waitErr := waitBeforeEnabledClientsRetry(ctx, attempt); waitErr != nil {
+				return waitErr
+			}
+		}
+
+		var connection *management.Connection
+		connection, err = api.Connection.Read(connectionID, management.Context(ctx))
+		if err != nil {
+			return err
+		}
+
+		enabledClients := toggleEnabledClients(connection.GetEnabledClients(), clientIDs, enable)
+
+		err = api.Connection.Update(
+			connectionID,
+			&management.Connection{EnabledClients: &enabledClients},
+			management.Context(ctx),
+		)
+		connectionReads.Invalidate(api, connectionID)
+		if err != nil {
+			if internalError.IsStatusConflict(err) {
+				continue
+			}
+			return err
+		}
+
+		connection, err = api.Connection.Read(connectionID, management.Context(ctx))
+		if err != nil {
+			return err
+		}
+
+		if enabledClientsApplied(connection.GetEnabledClients(), clientIDs, enable) {
+			return nil
+		}
+
+		err = fmt.Errorf(
+			"the enabled clients of connection %q were modified concurrently "+
+				"and the change to %s could not be applied after %d attempts",
+			connectionID,
+			describeClients(clientIDs),
+			attempt,
+		)
+	}
+
+	return err
+}
+
+// waitBeforeEnabledClientsRetry waits for an exponentially growing
+// and jittered delay, returning early if the context is done.
+func waitBeforeEnabledClientsRetry(ctx context.Context, attempt int) error {
+	delay := enabledClientsUpdateBackoff << (attempt - 2)
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// toggleEnabledClients returns a copy of the enabled clients
+// with the given clients either added or removed.
+func toggleEnabledClients(enabledClients []string, clientIDs []string, enable bool) []string {
+	result := make([]string, 0, len(enabledClients)+len(clientIDs))
+	for _, enabledClientID := range enabledClients {
+		if containsEnabledClient(clientIDs, enabledClientID) {
+			continue
+		}
+		result = append(result, enabledClientID)
+	}
+
+	if enable {
+		result = append(result, clientIDs...)
+	}
+
+	return result
+}
+
+func enabledClientsApplied(enabledClients []string, clientIDs []string, enable bool) bool {
+	for _, clientID := range clientIDs {
+		if containsEnabledClient(enabledClients, clientID) != enable {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeClients(clientIDs []string) string {
+	if len(clientIDs) == 1 {
+		return fmt.Sprintf("client %q", clientIDs[0])
+	}
+
+	quotedClientIDs := make([]string, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		quotedClientIDs = append(quotedClientIDs, strconv.Quote(clientID))
+	}
+
+	return "clients " + strings.Join(quotedClientIDs, ", ")
+}
+
+func containsEnabledClient(enabledClients []string, clientID string) bool {
+	for _, enabledClientID := range enabledClients {
+		if enabledClientID == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkEnabledClientsOverlap warns when the enabled clients configured on the
+// connection are about to disable clients that were enabled outside of it,
+// for example through the auth0_connection_client resource.
+func checkEnabledClientsOverlap(d *schema.ResourceData) diag.Diagnostics {
+	if d.GetRawConfig().GetAttr("enabled_clients").IsNull() {
+		return nil
+	}
+
+	_, clientsToDisable := value.Difference(d, "enabled_clients")
+	if len(clientsToDisable) == 0 {
+		return nil
+	}
+
+	clientIDs := make([]string, 0, len(clientsToDisable))
+	for _, clientID := range clientsToDisable {
+		clientIDs = append(clientIDs, clientID.(string))
+	}
+	sort.Strings(clientIDs)
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Enabled clients managed outside of the connection",
+			Detail: fmt.Sprintf(
+				"The clients %s were enabled on connection %q outside of the `enabled_clients` attribute "+
+					"and will be disabled. If they are managed by the auth0_connection_client or "+
+					"auth0_connection_clients resources, remove `enabled_clients` from the connection "+
+					"to avoid conflicting changes.",
+				strings.Join(clientIDs, ", "),
+				d.Id(),
+			),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "enabled_clients"}},
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package connection
+
+import (
+	"context"
+	"sync"
+
+	"github.com/auth0/go-auth0/management"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
+	"github.com/auth0/terraform-provider-auth0/internal/mutex"
+)
+
+// enabledClientsRemovals is shared by all the auth0_connection_client resources,
+// so that destroying many of them on the same connection computes and sends the
+// final enabled clients once per batch instead of once per client.
+var enabledClientsRemovals = newEnabledClientsRemovalBatcher()
+
+type enabledClientsRemovalKey struct {
+	api          *management.Management
+	connectionID string
+}
+
+type enabledClientsRemovalBatch struct {
+	ready     chan struct{}
+	done      chan struct{}
+	clientIDs []string
+	errs      map[string]error
+}
+
+func newEnabledClientsRemovalBatch(clientID string) *enabledClientsRemovalBatch {
+	return &enabledClientsRemovalBatch{
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+		clientIDs: []string{clientID},
+	}
+}
+
+// enabledClientsRemovalQueue tracks the batch being applied on a connection
+// and the batch collecting the removals arriving in the meantime.
+type enabledClientsRemovalQueue struct {
+	pending *enabledClientsRemovalBatch
+}
+
+// enabledClientsRemovalBatcher coalesces the removals of clients from a
+// connection. A removal on an idle connection is applied straight away, while
+// the removals arriving during that update are collected and applied together
+// in a single update as soon as it completes.
+type enabledClientsRemovalBatcher struct {
+	lock   sync.Mutex
+	queues map[enabledClientsRemovalKey]*enabledClientsRemovalQueue
+}
+
+func newEnabledClientsRemovalBatcher() *enabledClientsRemovalBatcher {
+	return &enabledClientsRemovalBatcher{
+		queues: make(map[enabledClientsRemovalKey]*enabledClientsRemovalQueue),
+	}
+}
+
+// Remove disables the given client on the connection, together with the other
+// clients removed from the same connection while a previous batch was applied.
+// The batch is applied using the context of the removal that started it.
+func (b *enabledClientsRemovalBatcher) Remove(
+	ctx context.Context,
+	api *management.Management,
+	connectionID string,
+	clientID string,
+) error {
+	key := enabledClientsRemovalKey{api: api, connectionID: connectionID}
+
+	b.lock.Lock()
+	queue, busy := b.queues[key]
+	if !busy {
+		b.queues[key] = &enabledClientsRemovalQueue{}
+		b.lock.Unlock()
+
+		batch := newEnabledClientsRemovalBatch(clientID)
+		b.flush(ctx, key, batch)
+
+		return batch.errs[clientID]
+	}
+
+	if batch := queue.pending; batch != nil {
+		batch.clientIDs = append(batch.clientIDs, clientID)
+		b.lock.Unlock()
+
+		<-batch.done
+		return batch.errs[clientID]
+	}
+
+	batch := newEnabledClientsRemovalBatch(clientID)
+	queue.pending = batch
+	b.lock.Unlock()
+
+	<-batch.ready
+	b.flush(ctx, key, batch)
+
+	return batch.errs[clientID]
+}
+
+// flush applies the batch, then hands the connection over
+// to the batch collected in the meantime, if there is one.
+func (b *enabledClientsRemovalBatcher) flush(
+	ctx context.Context,
+	key enabledClientsRemovalKey,
+	batch *enabledClientsRemovalBatch,
+) {
+	mutex.Global.Lock(key.connectionID)
+	batch.errs = removeEnabledClients(ctx, key.api, key.connectionID, batch.clientIDs)
+	mutex.Global.Unlock(key.connectionID)
+
+	// Removals arriving from now on start a new batch,
+	// so the collected client IDs can't change anymore.
+	b.lock.Lock()
+	next := b.queues[key].pending
+	b.queues[key].pending = nil
+	if next == nil {
+		delete(b.queues, key)
+	}
+	b.lock.Unlock()
+
+	close(batch.done)
+
+	if next != nil {
+		close(next.ready)
+	}
+}
+
+// removeEnabledClients disables the clients on the connection in a single
+// update. If that fails, the clients are disabled one by one, so that only
+// the removals causing the failure report an error.
+func removeEnabledClients(
+	ctx context.Context,
+	api *management.Management,
+	connectionID string,
+	clientIDs []string,
+) map[string]error {
+	errs := make(map[string]error, len(clientIDs))
+
+	err := updateEnabledClients(ctx, api, connectionID, clientIDs, false)
+	if err != nil && len(clientIDs) > 1 && !internalError.IsStatusNotFound(err) && ctx.Err() == nil {
+		for _, clientID := range clientIDs {
+			errs[clientID] = updateEnabledClients(ctx, api, connectionID, []string{clientID}, false)
+		}
+
+		return errs
+	}
+
+	for _, clientID := range clientIDs {
+		errs[clientID] = err
+	}
+
+	return errs
+}
@@ -0,0 +1,198 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteConnectionClientAppliesRemovalStraightAway(t *testing.T) {
+	enabledClients := []string{"client_1", "client_2"}
+	updates := 0
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			updates++
+
+			var body struct {
+				EnabledClients []string `json:"enabled_clients"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			enabledClients = body.EnabledClients
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"enabled_clients": enabledClients,
+		})
+		require.NoError(t, err)
+	})
+
+	data := schema.TestResourceDataRaw(t, NewClientResource().Schema, map[string]interface{}{
+		"connection_id": "con_123",
+		"client_id":     "client_1",
+	})
+	data.SetId("client_1")
+
+	diags := deleteConnectionClient(context.Background(), data, api)
+
+	assert.False(t, diags.HasError(), "Expected no errors, got %v", diags)
+	assert.Empty(t, data.Id())
+	assert.Equal(t, 1, updates)
+	assert.Equal(t, []string{"client_2"}, enabledClients)
+	assert.Empty(t, enabledClientsRemovals.queues)
+}
+
+func TestEnabledClientsRemovalBatcherCoalescesRemovalsDuringAnUpdate(t *testing.T) {
+	var lock sync.Mutex
+	enabledClients := []string{"client_1", "client_2", "client_3", "client_4"}
+	updates := 0
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		// Hold the first update until the other removals are queued.
+		once.Do(func() {
+			close(started)
+			<-release
+		})
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			updates++
+
+			var body struct {
+				EnabledClients []string `json:"enabled_clients"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			enabledClients = body.EnabledClients
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"enabled_clients": enabledClients,
+		})
+		require.NoError(t, err)
+	})
+
+	batcher := newEnabledClientsRemovalBatcher()
+	key := enabledClientsRemovalKey{api: api, connectionID: "con_123"}
+
+	var wg sync.WaitGroup
+	remove := func(clientID string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := batcher.Remove(context.Background(), api, "con_123", clientID)
+			assert.NoError(t, err)
+		}()
+	}
+
+	remove("client_1")
+	<-started
+
+	remove("client_2")
+	remove("client_3")
+
+	require.Eventually(t, func() bool {
+		batcher.lock.Lock()
+		defer batcher.lock.Unlock()
+
+		pending := batcher.queues[key].pending
+		return pending != nil && len(pending.clientIDs) == 2
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, []string{"client_4"}, enabledClients)
+	assert.Empty(t, batcher.queues)
+}
+
+func TestEnabledClientsRemovalBatcherReportsErrorsToAllRemovals(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The connection does not exist"}`))
+		require.NoError(t, err)
+	})
+
+	batcher := newEnabledClientsRemovalBatcher()
+
+	var wg sync.WaitGroup
+	for _, clientID := range []string{"client_1", "client_2"} {
+		clientID := clientID
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := batcher.Remove(context.Background(), api, "con_123", clientID)
+			assert.EqualError(t, err, "404 Not Found: The connection does not exist")
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, batcher.queues)
+}
+
+func TestRemoveEnabledClientsRetriesOneByOneWhenTheBatchFails(t *testing.T) {
+	enabledClients := []string{"client_1", "client_2", "client_3"}
+	updates := 0
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			updates++
+
+			var body struct {
+				EnabledClients []string `json:"enabled_clients"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			if !containsEnabledClient(body.EnabledClients, "client_2") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, err := w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"client_2 can't be disabled"}`))
+				require.NoError(t, err)
+				return
+			}
+
+			enabledClients = body.EnabledClients
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"enabled_clients": enabledClients,
+		})
+		require.NoError(t, err)
+	})
+
+	errs := removeEnabledClients(context.Background(), api, "con_123", []string{"client_1", "client_2"})
+
+	assert.NoError(t, errs["client_1"])
+	assert.EqualError(t, errs["client_2"], "400 Bad Request: client_2 can't be disabled")
+	assert.Equal(t, 3, updates)
+	assert.Equal(t, []string{"client_2", "client_3"}, enabledClients)
+}
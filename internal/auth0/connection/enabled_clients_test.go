@@ -0,0 +1,281 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEnabledClients(t *testing.T) {
+	withoutEnabledClientsBackoff(t)
+
+	var testCases = []struct {
+		name                   string
+		givenEnabledClients    []string
+		givenClientID          string
+		givenEnable            bool
+		givenConflicts         int
+		givenOverwrites        int
+		expectedEnabledClients []string
+		expectedUpdates        int
+		expectedError          string
+	}{
+		{
+			name:                   "it enables a client",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			expectedEnabledClients: []string{"client_1", "client_2"},
+			expectedUpdates:        1,
+		},
+		{
+			name:                   "it does not duplicate an already enabled client",
+			givenEnabledClients:    []string{"client_1", "client_2"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			expectedEnabledClients: []string{"client_1", "client_2"},
+			expectedUpdates:        1,
+		},
+		{
+			name:                   "it disables the last client",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_1",
+			givenEnable:            false,
+			expectedEnabledClients: []string{},
+			expectedUpdates:        1,
+		},
+		{
+			name:                   "it disables a client and keeps the others enabled",
+			givenEnabledClients:    []string{"client_1", "client_2", "client_3"},
+			givenClientID:          "client_2",
+			givenEnable:            false,
+			expectedEnabledClients: []string{"client_1", "client_3"},
+			expectedUpdates:        1,
+		},
+		{
+			name:                   "it does not fail when disabling a client that is not enabled",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            false,
+			expectedEnabledClients: []string{"client_1"},
+			expectedUpdates:        1,
+		},
+		{
+			name:                   "it retries when the api responds with a conflict",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			givenConflicts:         2,
+			expectedEnabledClients: []string{"client_1", "client_2"},
+			expectedUpdates:        3,
+		},
+		{
+			name:                   "it retries when the change was overwritten concurrently",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			givenOverwrites:        1,
+			expectedEnabledClients: []string{"client_1", "client_2"},
+			expectedUpdates:        2,
+		},
+		{
+			name:                   "it gives up after too many concurrent modifications",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			givenOverwrites:        maxEnabledClientsUpdateAttempts,
+			expectedEnabledClients: []string{"client_1"},
+			expectedUpdates:        maxEnabledClientsUpdateAttempts,
+			expectedError: `the enabled clients of connection "con_123" were modified concurrently ` +
+				`and the change to client "client_2" could not be applied after 5 attempts`,
+		},
+		{
+			name:                   "it gives up after too many conflicts",
+			givenEnabledClients:    []string{"client_1"},
+			givenClientID:          "client_2",
+			givenEnable:            true,
+			givenConflicts:         maxEnabledClientsUpdateAttempts,
+			expectedEnabledClients: []string{"client_1"},
+			expectedUpdates:        maxEnabledClientsUpdateAttempts,
+			expectedError:          "409 Conflict: Connection is being updated",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enabledClients := testCase.givenEnabledClients
+			conflicts := testCase.givenConflicts
+			overwrites := testCase.givenOverwrites
+			updates := 0
+
+			api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method == http.MethodPatch {
+					updates++
+
+					if conflicts > 0 {
+						conflicts--
+						w.WriteHeader(http.StatusConflict)
+						_, err := w.Write([]byte(`{"statusCode":409,"error":"Conflict","message":"Connection is being updated"}`))
+						require.NoError(t, err)
+						return
+					}
+
+					var body struct {
+						EnabledClients []string `json:"enabled_clients"`
+					}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					require.NotNil(t, body.EnabledClients)
+
+					if overwrites > 0 {
+						overwrites--
+					} else {
+						enabledClients = body.EnabledClients
+					}
+				}
+
+				err := json.NewEncoder(w).Encode(map[string]interface{}{
+					"id":              "con_123",
+					"enabled_clients": enabledClients,
+				})
+				require.NoError(t, err)
+			})
+
+			err := updateEnabledClients(context.Background(), api, "con_123", []string{testCase.givenClientID}, testCase.givenEnable)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedEnabledClients, enabledClients)
+			assert.Equal(t, testCase.expectedUpdates, updates)
+		})
+	}
+}
+
+func TestUpdateEnabledClientsStopsRetryingWhenTheContextIsDone(t *testing.T) {
+	updates := 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			updates++
+			cancel()
+
+			w.WriteHeader(http.StatusConflict)
+			_, err := w.Write([]byte(`{"statusCode":409,"error":"Conflict","message":"Connection is being updated"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"id":"con_123","enabled_clients":["client_1"]}`))
+		require.NoError(t, err)
+	})
+
+	err := updateEnabledClients(ctx, api, "con_123", []string{"client_2"}, true)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, updates)
+}
+
+// withoutEnabledClientsBackoff removes the delay between the
+// attempts to update the enabled clients for the duration of the test.
+func withoutEnabledClientsBackoff(t *testing.T) {
+	t.Helper()
+
+	backoff := enabledClientsUpdateBackoff
+	enabledClientsUpdateBackoff = 0
+	t.Cleanup(func() {
+		enabledClientsUpdateBackoff = backoff
+	})
+}
+
+func TestCheckEnabledClientsOverlap(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenEnabledClients cty.Value
+		expectedWarning     string
+	}{
+		{
+			name:                "enabled clients are not configured",
+			givenEnabledClients: cty.NullVal(cty.Set(cty.String)),
+		},
+		{
+			name:                "enabled clients only add clients",
+			givenEnabledClients: toStringSetVal([]string{"client_1", "client_2", "client_3"}),
+		},
+		{
+			name:                "enabled clients disable clients enabled outside of them",
+			givenEnabledClients: toStringSetVal([]string{"client_3"}),
+			expectedWarning:     "The clients client_1, client_2 were enabled on connection \"con_123\"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resource := NewResource()
+
+			state := &terraform.InstanceState{
+				ID: "con_123",
+				Attributes: map[string]string{
+					"id":                "con_123",
+					"name":              "Acme",
+					"strategy":          "auth0",
+					"enabled_clients.#": "2",
+					"enabled_clients." + strconv.Itoa(schema.HashString("client_1")): "client_1",
+					"enabled_clients." + strconv.Itoa(schema.HashString("client_2")): "client_2",
+				},
+				RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+					"name":            cty.StringVal("Acme"),
+					"strategy":        cty.StringVal("auth0"),
+					"enabled_clients": testCase.givenEnabledClients,
+				}),
+			}
+
+			config := map[string]interface{}{
+				"name":     "Acme",
+				"strategy": "auth0",
+			}
+			if !testCase.givenEnabledClients.IsNull() {
+				enabledClients := make([]interface{}, 0)
+				for _, clientID := range testCase.givenEnabledClients.AsValueSlice() {
+					enabledClients = append(enabledClients, clientID.AsString())
+				}
+				config["enabled_clients"] = enabledClients
+			}
+
+			diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+
+			data, err := schema.InternalMap(resource.Schema).Data(state, diff)
+			require.NoError(t, err)
+
+			diagnostics := checkEnabledClientsOverlap(data)
+			if testCase.expectedWarning == "" {
+				assert.Empty(t, diagnostics)
+				return
+			}
+
+			require.Len(t, diagnostics, 1)
+			assert.Equal(t, diag.Warning, diagnostics[0].Severity)
+			assert.Contains(t, diagnostics[0].Detail, testCase.expectedWarning)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package connection
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,7 +14,11 @@ import (
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
-func expandConnection(d *schema.ResourceData, api *management.Management) (*management.Connection, diag.Diagnostics) {
+func expandConnection(
+	ctx context.Context,
+	d *schema.ResourceData,
+	api *management.Management,
+) (*management.Connection, diag.Diagnostics) {
 	config := d.GetRawConfig()
 
 	connection := &management.Connection{
@@ -37,10 +44,12 @@ func expandConnection(d *schema.ResourceData, api *management.Management) (*mana
 	strategy := d.Get("strategy").(string)
 	showAsButton := value.Bool(config.GetAttr("show_as_button"))
 
+	// Without an options block the connection options are left nil, so that they
+	// are omitted from the request and the options set through the API are kept.
 	config.GetAttr("options").ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
 		switch strategy {
 		case management.ConnectionStrategyAuth0:
-			connection.Options, diagnostics = expandConnectionOptionsAuth0(d, options, api)
+			connection.Options, diagnostics = expandConnectionOptionsAuth0(ctx, d, options, api)
 		case management.ConnectionStrategyGoogleOAuth2:
 			connection.Options, diagnostics = expandConnectionOptionsGoogleOAuth2(d, options)
 		case management.ConnectionStrategyGoogleApps:
@@ -141,6 +150,7 @@ func expandConnectionOptionsGitHub(
 }
 
 func expandConnectionOptionsAuth0(
+	ctx context.Context,
 	d *schema.ResourceData,
 	config cty.Value,
 	api *management.Management,
@@ -279,22 +289,104 @@ func expandConnectionOptionsAuth0(
 	}
 
 	if !d.IsNewResource() {
-		apiConn, err := api.Connection.Read(d.Id())
+		apiConn, err := api.Connection.Read(d.Id(), management.Context(ctx))
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
+		apiOptions := apiConn.Options.(*management.ConnectionOptions)
+
 		diags := checkForUnmanagedConfigurationSecrets(
 			options.GetConfiguration(),
-			apiConn.Options.(*management.ConnectionOptions).GetConfiguration(),
+			apiOptions.GetConfiguration(),
 		)
 
 		if diags.HasError() {
 			return nil, diags
 		}
+
+		options.Validation = mergeUnmanagedValidationOptions(options.Validation, apiOptions.Validation)
+	}
+
+	diagnostics := checkDatabaseSetUserRootAttributes(options)
+	diagnostics = append(diagnostics, checkDatabaseImportMode(options)...)
+
+	return options, diagnostics
+}
+
+// mergeUnmanagedValidationOptions keeps the validation settings returned by the
+// API that we don't manage, as the validation object is replaced as a whole.
+func mergeUnmanagedValidationOptions(validation, validationFromAPI map[string]interface{}) map[string]interface{} {
+	if validation == nil {
+		return nil
 	}
 
-	return options, nil
+	for key, setting := range validationFromAPI {
+		if key == "username" {
+			continue
+		}
+		validation[key] = setting
+	}
+
+	return validation
+}
+
+// checkDatabaseSetUserRootAttributes warns when set_user_root_attributes is
+// configured on a database connection that doesn't import its users from a
+// custom database, as the profile is then never federated from elsewhere.
+func checkDatabaseSetUserRootAttributes(options *management.ConnectionOptions) diag.Diagnostics {
+	if options.SetUserAttributes == nil || options.GetImportMode() {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Ineffective set_user_root_attributes",
+			Detail: fmt.Sprintf(
+				"The set_user_root_attributes value %q has no effect on database connections, "+
+					"unless their users are imported from a custom database with `import_mode` enabled.",
+				options.GetSetUserAttributes(),
+			),
+			AttributePath: cty.GetAttrPath("options").IndexInt(0).GetAttr("set_user_root_attributes"),
+		},
+	}
+}
+
+// checkDatabaseImportMode warns when import_mode is enabled on a database
+// connection that can't lazily migrate its users from a custom database.
+func checkDatabaseImportMode(options *management.ConnectionOptions) diag.Diagnostics {
+	if !options.GetImportMode() {
+		return nil
+	}
+
+	importModePath := cty.GetAttrPath("options").IndexInt(0).GetAttr("import_mode")
+
+	if !options.GetEnabledDatabaseCustomization() {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Ineffective import_mode",
+				Detail: "Users can only be imported from a custom database, " +
+					"set `enabled_database_customization` to true or disable `import_mode`.",
+				AttributePath: importModePath,
+			},
+		}
+	}
+
+	if options.GetCustomScripts()["get_user"] == "" {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Missing get_user script",
+				Detail: "Importing users from a custom database also requires a `get_user` script " +
+					"in `custom_scripts`, to look up the users that don't exist in Auth0 yet.",
+				AttributePath: importModePath,
+			},
+		}
+	}
+
+	return nil
 }
 
 func expandConnectionOptionsGoogleOAuth2(
@@ -357,7 +449,9 @@ func expandConnectionOptionsOAuth2(
 		Scripts:            value.MapOfStrings(config.GetAttr("scripts")),
 	}
 
-	expandConnectionOptionsScopes(d, options)
+	if options.Scope = expandConnectionOptionsOrderedScope(config); options.Scope == nil {
+		expandConnectionOptionsScopes(d, options)
+	}
 
 	var err error
 	options.UpstreamParams, err = value.MapFromJSON(config.GetAttr("upstream_params"))
@@ -614,7 +708,9 @@ func expandConnectionOptionsOIDC(
 		NonPersistentAttrs:    value.Strings(config.GetAttr("non_persistent_attrs")),
 	}
 
-	expandConnectionOptionsScopes(d, options)
+	if options.Scope = expandConnectionOptionsOrderedScope(config); options.Scope == nil {
+		expandConnectionOptionsScopes(d, options)
+	}
 
 	var err error
 	options.UpstreamParams, err = value.MapFromJSON(config.GetAttr("upstream_params"))
@@ -739,3 +835,14 @@ func expandConnectionOptionsScopes(d *schema.ResourceData, s scoper) {
 		s.SetScopes(false, scope.(string))
 	}
 }
+
+// expandConnectionOptionsOrderedScope joins the scopes configured through
+// the ordered scope option, returning nil when they're not configured.
+func expandConnectionOptionsOrderedScope(config cty.Value) *string {
+	scopes := value.Strings(config.GetAttr("scope"))
+	if scopes == nil {
+		return nil
+	}
+
+	return auth0.String(strings.Join(*scopes, " "))
+}
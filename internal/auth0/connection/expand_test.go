@@ -1,11 +1,18 @@
 package connection
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCheckForUnmanagedConfigurationSecrets(t *testing.T) {
@@ -62,3 +69,239 @@ func TestCheckForUnmanagedConfigurationSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeUnmanagedValidationOptions(t *testing.T) {
+	var testCases = []struct {
+		name                   string
+		givenValidation        map[string]interface{}
+		givenValidationFromAPI map[string]interface{}
+		expectedValidation     map[string]interface{}
+	}{
+		{
+			name:            "validation is not configured",
+			givenValidation: nil,
+			givenValidationFromAPI: map[string]interface{}{
+				"password": map[string]interface{}{"min": float64(8)},
+			},
+			expectedValidation: nil,
+		},
+		{
+			name: "api has no validation",
+			givenValidation: map[string]interface{}{
+				"username": map[string]*int{"min": auth0.Int(5)},
+			},
+			givenValidationFromAPI: nil,
+			expectedValidation: map[string]interface{}{
+				"username": map[string]*int{"min": auth0.Int(5)},
+			},
+		},
+		{
+			name: "unmanaged validation settings are kept",
+			givenValidation: map[string]interface{}{
+				"username": map[string]*int{"min": auth0.Int(5)},
+			},
+			givenValidationFromAPI: map[string]interface{}{
+				"username": map[string]interface{}{"min": float64(1), "max": float64(15)},
+				"password": map[string]interface{}{"min": float64(8)},
+			},
+			expectedValidation: map[string]interface{}{
+				"username": map[string]*int{"min": auth0.Int(5)},
+				"password": map[string]interface{}{"min": float64(8)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualValidation := mergeUnmanagedValidationOptions(
+				testCase.givenValidation,
+				testCase.givenValidationFromAPI,
+			)
+
+			assert.Equal(t, testCase.expectedValidation, actualValidation)
+		})
+	}
+}
+
+func TestExpandConnectionOptionsOrderedScope(t *testing.T) {
+	config := newRawOptions(t, map[string]cty.Value{
+		"scope": cty.ListVal([]cty.Value{
+			cty.StringVal("openid"),
+			cty.StringVal("profile"),
+			cty.StringVal("email"),
+			cty.StringVal("address"),
+		}),
+	}).Index(cty.NumberIntVal(0))
+
+	scope := expandConnectionOptionsOrderedScope(config)
+	if assert.NotNil(t, scope) {
+		assert.Equal(t, "openid profile email address", *scope)
+	}
+
+	unsetConfig := newRawOptions(t, map[string]cty.Value{}).Index(cty.NumberIntVal(0))
+	assert.Nil(t, expandConnectionOptionsOrderedScope(unsetConfig))
+}
+
+func TestExpandConnectionWithoutOptions(t *testing.T) {
+	resource := NewResource()
+	data := resource.Data(&terraform.InstanceState{
+		ID: "con_123",
+		Attributes: map[string]string{
+			"id":                  "con_123",
+			"name":                "Acme",
+			"strategy":            "okta",
+			"options.#":           "1",
+			"options.0.client_id": "someClientID",
+			"options.0.domain":    "example.okta.com",
+		},
+		RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+			"name":         cty.StringVal("Acme"),
+			"strategy":     cty.StringVal("okta"),
+			"display_name": cty.StringVal("Acme Okta"),
+			// Terraform sends an omitted block as an empty list.
+			"options": cty.ListValEmpty(resourceSchema["options"].Elem.(*schema.Resource).CoreConfigSchema().ImpliedType()),
+		}),
+	})
+
+	connection, diagnostics := expandConnection(context.Background(), data, nil)
+	require.False(t, diagnostics.HasError(), diagnostics)
+
+	// The options set through the API are kept when they are left out of the request.
+	assert.Nil(t, connection.Options)
+
+	body, err := json.Marshal(connection)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"display_name":"Acme Okta"}`, string(body))
+}
+
+func TestCheckDatabaseSetUserRootAttributes(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenOptions        *management.ConnectionOptions
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "set_user_root_attributes is not set",
+			givenOptions:        &management.ConnectionOptions{},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "set_user_root_attributes is set with import mode enabled",
+			givenOptions: &management.ConnectionOptions{
+				SetUserAttributes: auth0.String("on_both_login"),
+				ImportMode:        auth0.Bool(true),
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "set_user_root_attributes is set without import mode",
+			givenOptions: &management.ConnectionOptions{
+				SetUserAttributes: auth0.String("on_first_login"),
+				ImportMode:        auth0.Bool(false),
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Ineffective set_user_root_attributes",
+					Detail: "The set_user_root_attributes value \"on_first_login\" has no effect on database " +
+						"connections, unless their users are imported from a custom database with `import_mode` enabled.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "options"},
+						cty.IndexStep{Key: cty.NumberIntVal(0)},
+						cty.GetAttrStep{Name: "set_user_root_attributes"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := checkDatabaseSetUserRootAttributes(testCase.givenOptions)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestSetUserRootAttributesValues(t *testing.T) {
+	options := NewResource().Schema["options"].Elem.(*schema.Resource)
+	validateFunc := options.Schema["set_user_root_attributes"].ValidateFunc
+
+	for _, givenValue := range []string{"on_each_login", "on_first_login", "on_both_login"} {
+		_, errs := validateFunc(givenValue, "set_user_root_attributes")
+		assert.Empty(t, errs, givenValue)
+	}
+
+	_, errs := validateFunc("never_on_login", "set_user_root_attributes")
+	assert.Len(t, errs, 1)
+}
+
+func TestCheckDatabaseImportMode(t *testing.T) {
+	importModePath := cty.Path{
+		cty.GetAttrStep{Name: "options"},
+		cty.IndexStep{Key: cty.NumberIntVal(0)},
+		cty.GetAttrStep{Name: "import_mode"},
+	}
+
+	var testCases = []struct {
+		name                string
+		givenOptions        *management.ConnectionOptions
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "import mode is disabled",
+			givenOptions:        &management.ConnectionOptions{ImportMode: auth0.Bool(false)},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "import mode is enabled with a get_user script",
+			givenOptions: &management.ConnectionOptions{
+				ImportMode:                   auth0.Bool(true),
+				EnabledDatabaseCustomization: auth0.Bool(true),
+				CustomScripts:                &map[string]string{"login": "login", "get_user": "getByEmail"},
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "import mode is enabled without customization",
+			givenOptions: &management.ConnectionOptions{
+				ImportMode: auth0.Bool(true),
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Ineffective import_mode",
+					Detail: "Users can only be imported from a custom database, " +
+						"set `enabled_database_customization` to true or disable `import_mode`.",
+					AttributePath: importModePath,
+				},
+			},
+		},
+		{
+			name: "import mode is enabled without a get_user script",
+			givenOptions: &management.ConnectionOptions{
+				ImportMode:                   auth0.Bool(true),
+				EnabledDatabaseCustomization: auth0.Bool(true),
+				CustomScripts:                &map[string]string{"login": "login"},
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Missing get_user script",
+					Detail: "Importing users from a custom database also requires a `get_user` script " +
+						"in `custom_scripts`, to look up the users that don't exist in Auth0 yet.",
+					AttributePath: importModePath,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := checkDatabaseImportMode(testCase.givenOptions)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
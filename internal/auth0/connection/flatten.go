@@ -2,6 +2,8 @@ package connection
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
@@ -10,6 +12,36 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 )
 
+func flattenConnectionSigningKeys(options interface{}) ([]interface{}, error) {
+	samlOptions, ok := options.(*management.ConnectionOptionsSAML)
+	if !ok || samlOptions == nil {
+		return nil, nil
+	}
+
+	var signingKeys []interface{}
+
+	if samlOptions.GetCert() != "" {
+		subject, err := structure.FlattenJsonToString(samlOptions.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		signingKeys = append(signingKeys, map[string]interface{}{
+			"cert":    samlOptions.GetCert(),
+			"subject": subject,
+		})
+	}
+
+	if samlOptions.SigningKey != nil {
+		signingKeys = append(signingKeys, map[string]interface{}{
+			"cert":    samlOptions.SigningKey.GetCert(),
+			"subject": "",
+		})
+	}
+
+	return signingKeys, nil
+}
+
 func flattenConnectionOptions(d *schema.ResourceData, options interface{}) ([]interface{}, diag.Diagnostics) {
 	if options == nil {
 		return nil, nil
@@ -56,6 +88,15 @@ func flattenConnectionOptions(d *schema.ResourceData, options interface{}) ([]in
 		m, diags = flattenConnectionOptionsSAML(d, connectionOptions)
 	}
 
+	// Most strategies don't return a strategy version. It's explicitly
+	// cleared in that case, so a value left over in state, for example by
+	// the V0 state upgrade, is never kept around once the connection is read.
+	if optionsMap, ok := m.(map[string]interface{}); ok {
+		if _, ok := optionsMap["strategy_version"]; !ok {
+			optionsMap["strategy_version"] = 0
+		}
+	}
+
 	return []interface{}{m}, diags
 }
 
@@ -64,8 +105,8 @@ func flattenConnectionOptionsGitHub(options *management.ConnectionOptionsGitHub)
 		"client_id":                options.GetClientID(),
 		"client_secret":            options.GetClientSecret(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
-		"scopes":                   options.Scopes(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
+		"scopes":                   sortedUniqueStrings(options.Scopes()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -77,13 +118,60 @@ func flattenConnectionOptionsGitHub(options *management.ConnectionOptionsGitHub)
 	return m, nil
 }
 
+// flattenConnectionEnabledClients makes sure a connection without any
+// enabled clients is always read back as an empty list, as some tenants
+// respond with null and others with an empty list in that case.
+func flattenConnectionEnabledClients(enabledClients []string) []string {
+	if enabledClients == nil {
+		return []string{}
+	}
+
+	return enabledClients
+}
+
+// flattenConnectionOptionsNonPersistentAttrs trims, dedupes and sorts the
+// given attributes so that the way the API returns them never produces a diff.
+func flattenConnectionOptionsNonPersistentAttrs(attrs []string) []string {
+	if attrs == nil {
+		return nil
+	}
+
+	trimmedAttrs := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr = strings.TrimSpace(attr); attr != "" {
+			trimmedAttrs = append(trimmedAttrs, attr)
+		}
+	}
+
+	return sortedUniqueStrings(trimmedAttrs)
+}
+
+// sortedUniqueStrings dedupes and sorts the given values so
+// that the order in which the API returns them never produces a diff.
+func sortedUniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	sortedValues := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		sortedValues = append(sortedValues, value)
+	}
+
+	sort.Strings(sortedValues)
+
+	return sortedValues
+}
+
 func flattenConnectionOptionsWindowsLive(options *management.ConnectionOptionsWindowsLive) (interface{}, diag.Diagnostics) {
 	m := map[string]interface{}{
 		"client_id":                options.GetClientID(),
 		"client_secret":            options.GetClientSecret(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"strategy_version":         options.GetStrategyVersion(),
 	}
 
@@ -115,7 +203,7 @@ func flattenConnectionOptionsAuth0(
 		"requires_username":              options.GetRequiresUsername(),
 		"custom_scripts":                 options.GetCustomScripts(),
 		"configuration":                  dbSecretConfig, // Values do not get read back.
-		"non_persistent_attrs":           options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":           flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"set_user_root_attributes":       options.GetSetUserAttributes(),
 	}
 
@@ -134,14 +222,8 @@ func flattenConnectionOptionsAuth0(
 	if options.MFA != nil {
 		m["mfa"] = []interface{}{options.MFA}
 	}
-	if options.Validation != nil {
-		m["validation"] = []interface{}{
-			map[string]interface{}{
-				"username": []interface{}{
-					options.Validation["username"],
-				},
-			},
-		}
+	if validation := flattenConnectionOptionsValidation(options.Validation); validation != nil {
+		m["validation"] = validation
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -153,6 +235,28 @@ func flattenConnectionOptionsAuth0(
 	return m, nil
 }
 
+// flattenConnectionOptionsValidation only reads back the username validation,
+// as the API can return other validation settings that we don't manage.
+func flattenConnectionOptionsValidation(validation map[string]interface{}) []interface{} {
+	username, ok := validation["username"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	usernameValidation := make(map[string]interface{})
+	for _, key := range []string{"min", "max"} {
+		if v, ok := username[key]; ok {
+			usernameValidation[key] = v
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"username": []interface{}{usernameValidation},
+		},
+	}
+}
+
 // checkForUnmanagedConfigurationSecrets is used to assess keys diff because values are sent back encrypted.
 func checkForUnmanagedConfigurationSecrets(configFromTF, configFromAPI map[string]string) diag.Diagnostics {
 	var warnings diag.Diagnostics
@@ -185,7 +289,7 @@ func flattenConnectionOptionsGoogleOAuth2(
 		"allowed_audiences":        options.GetAllowedAudiences(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -208,7 +312,7 @@ func flattenConnectionOptionsGoogleApps(
 		"api_enable_users":         options.GetEnableUsersAPI(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"domain_aliases":           options.GetDomainAliases(),
 		"icon_url":                 options.GetLogoURL(),
 	}
@@ -226,12 +330,13 @@ func flattenConnectionOptionsOAuth2(options *management.ConnectionOptionsOAuth2)
 	m := map[string]interface{}{
 		"client_id":                options.GetClientID(),
 		"client_secret":            options.GetClientSecret(),
+		"scope":                    options.Scopes(),
 		"scopes":                   options.Scopes(),
 		"token_endpoint":           options.GetTokenURL(),
 		"authorization_endpoint":   options.GetAuthorizationURL(),
 		"scripts":                  options.GetScripts(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"icon_url":                 options.GetLogoURL(),
 		"pkce_enabled":             options.GetPKCEEnabled(),
 	}
@@ -251,7 +356,7 @@ func flattenConnectionOptionsFacebook(options *management.ConnectionOptionsFaceb
 		"client_secret":            options.GetClientSecret(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -271,7 +376,7 @@ func flattenConnectionOptionsApple(options *management.ConnectionOptionsApple) (
 		"key_id":                   options.GetKeyID(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -290,7 +395,7 @@ func flattenConnectionOptionsLinkedin(options *management.ConnectionOptionsLinke
 		"strategy_version":         options.GetStrategyVersion(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -309,7 +414,7 @@ func flattenConnectionOptionsSalesforce(options *management.ConnectionOptionsSal
 		"community_base_url":       options.GetCommunityBaseURL(),
 		"scopes":                   options.Scopes(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -337,14 +442,7 @@ func flattenConnectionOptionsSMS(options *management.ConnectionOptionsSMS) (inte
 		"forward_request_info":   options.GetForwardRequestInfo(),
 	}
 
-	if options.OTP != nil {
-		m["totp"] = []interface{}{
-			map[string]interface{}{
-				"time_step": options.OTP.GetTimeStep(),
-				"length":    options.OTP.GetLength(),
-			},
-		}
-	}
+	m["totp"] = flattenConnectionOptionsTOTP(options.OTP)
 
 	if options.GatewayAuthentication != nil {
 		m["gateway_authentication"] = []interface{}{
@@ -375,6 +473,7 @@ func flattenConnectionOptionsOIDC(options *management.ConnectionOptionsOIDC) (in
 		"tenant_domain":            options.GetTenantDomain(),
 		"domain_aliases":           options.GetDomainAliases(),
 		"type":                     options.GetType(),
+		"scope":                    options.Scopes(),
 		"scopes":                   options.Scopes(),
 		"issuer":                   options.GetIssuer(),
 		"jwks_uri":                 options.GetJWKSURI(),
@@ -383,7 +482,7 @@ func flattenConnectionOptionsOIDC(options *management.ConnectionOptionsOIDC) (in
 		"userinfo_endpoint":        options.GetUserInfoEndpoint(),
 		"authorization_endpoint":   options.GetAuthorizationEndpoint(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -407,7 +506,7 @@ func flattenConnectionOptionsOkta(options *management.ConnectionOptionsOkta) (in
 		"token_endpoint":           options.GetTokenEndpoint(),
 		"userinfo_endpoint":        options.GetUserInfoEndpoint(),
 		"authorization_endpoint":   options.GetAuthorizationEndpoint(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
 		"icon_url":                 options.GetLogoURL(),
 	}
@@ -421,6 +520,21 @@ func flattenConnectionOptionsOkta(options *management.ConnectionOptionsOkta) (in
 	return m, nil
 }
 
+// flattenConnectionOptionsTOTP returns nil when the connection has no
+// one-time password settings, so that the computed block keeps its state.
+func flattenConnectionOptionsTOTP(otp *management.ConnectionOptionsOTP) []interface{} {
+	if otp == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"time_step": otp.GetTimeStep(),
+			"length":    otp.GetLength(),
+		},
+	}
+}
+
 func flattenConnectionOptionsEmail(options *management.ConnectionOptionsEmail) (interface{}, diag.Diagnostics) {
 	m := map[string]interface{}{
 		"name":                     options.GetName(),
@@ -431,17 +545,10 @@ func flattenConnectionOptionsEmail(options *management.ConnectionOptionsEmail) (
 		"disable_signup":           options.GetDisableSignup(),
 		"brute_force_protection":   options.GetBruteForceProtection(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
-	if options.OTP != nil {
-		m["totp"] = []interface{}{
-			map[string]interface{}{
-				"time_step": options.OTP.GetTimeStep(),
-				"length":    options.OTP.GetLength(),
-			},
-		}
-	}
+	m["totp"] = flattenConnectionOptionsTOTP(options.OTP)
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
 	if err != nil {
@@ -476,7 +583,7 @@ func flattenConnectionOptionsAD(options *management.ConnectionOptionsAD) (interf
 		"disable_cache":            options.GetDisableCache(),
 		"brute_force_protection":   options.GetBruteForceProtection(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -505,7 +612,7 @@ func flattenConnectionOptionsAzureAD(options *management.ConnectionOptionsAzureA
 		"max_groups_to_retrieve":                 options.GetMaxGroupsToRetrieve(),
 		"scopes":                                 options.Scopes(),
 		"set_user_root_attributes":               options.GetSetUserAttributes(),
-		"non_persistent_attrs":                   options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":                   flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"should_trust_email_verified_connection": options.GetTrustEmailVerified(),
 	}
 
@@ -529,7 +636,7 @@ func flattenConnectionOptionsADFS(options *management.ConnectionOptionsADFS) (in
 		"api_enable_users":                       options.GetEnableUsersAPI(),
 		"should_trust_email_verified_connection": options.GetTrustEmailVerified(),
 		"set_user_root_attributes":               options.GetSetUserAttributes(),
-		"non_persistent_attrs":                   options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":                   flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 	}
 
 	upstreamParams, err := structure.FlattenJsonToString(options.UpstreamParams)
@@ -561,7 +668,7 @@ func flattenConnectionOptionsSAML(
 		"request_template":         options.GetRequestTemplate(),
 		"user_id_attribute":        options.GetUserIDAttribute(),
 		"set_user_root_attributes": options.GetSetUserAttributes(),
-		"non_persistent_attrs":     options.GetNonPersistentAttrs(),
+		"non_persistent_attrs":     flattenConnectionOptionsNonPersistentAttrs(options.GetNonPersistentAttrs()),
 		"entity_id":                options.GetEntityID(),
 		"metadata_url":             options.GetMetadataURL(),
 		"metadata_xml":             d.Get("options.0.metadata_xml").(string), // Does not get read back.
@@ -578,9 +685,16 @@ func flattenConnectionOptionsSAML(
 	}
 
 	if options.SigningKey != nil {
+		// The private key is write-only, so we keep the one from the state to
+		// detect when it gets rotated without marking the block as computed.
+		signingKey := options.SigningKey.GetKey()
+		if signingKey == "" {
+			signingKey = d.Get("options.0.signing_key.0.key").(string)
+		}
+
 		m["signing_key"] = []interface{}{
 			map[string]interface{}{
-				"key":  options.SigningKey.GetKey(),
+				"key":  signingKey,
 				"cert": options.SigningKey.GetCert(),
 			},
 		}
@@ -600,3 +714,16 @@ func flattenConnectionOptionsSAML(
 
 	return m, nil
 }
+
+func flattenConnectionsForDataSource(connections []*management.Connection) []interface{} {
+	result := make([]interface{}, 0, len(connections))
+	for _, connection := range connections {
+		result = append(result, map[string]interface{}{
+			"id":       connection.GetID(),
+			"name":     connection.GetName(),
+			"strategy": connection.GetStrategy(),
+		})
+	}
+
+	return result
+}
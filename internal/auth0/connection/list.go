@@ -0,0 +1,36 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+// listAllConnections returns the connections matching
+// the given request options across all the pages.
+func listAllConnections(
+	ctx context.Context,
+	api *management.Management,
+	opts ...management.RequestOption,
+) ([]*management.Connection, error) {
+	options := append([]management.RequestOption{management.Context(ctx)}, opts...)
+
+	var connections []*management.Connection
+	page := 0
+	for {
+		connectionList, err := api.Connection.List(append(options, management.Page(page))...)
+		if err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, connectionList.Connections...)
+
+		if !connectionList.HasNext() {
+			break
+		}
+
+		page++
+	}
+
+	return connections, nil
+}
@@ -0,0 +1,63 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAllConnections(t *testing.T) {
+	pages := map[string][]map[string]string{
+		"0": {{"id": "con_1", "name": "first"}, {"id": "con_2", "name": "second"}},
+		"1": {{"id": "con_3", "name": "third"}},
+	}
+	var requestedPages []string
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections", r.URL.Path)
+		assert.Equal(t, "id,name", r.URL.Query().Get("fields"))
+
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"start":       (len(requestedPages) - 1) * 2,
+			"limit":       2,
+			"length":      len(pages[page]),
+			"total":       3,
+			"connections": pages[page],
+		})
+		require.NoError(t, err)
+	})
+
+	connections, err := listAllConnections(context.Background(), api, management.IncludeFields("id", "name"))
+	require.NoError(t, err)
+
+	var connectionIDs []string
+	for _, connection := range connections {
+		connectionIDs = append(connectionIDs, connection.GetID())
+	}
+
+	assert.Equal(t, []string{"con_1", "con_2", "con_3"}, connectionIDs)
+	assert.Equal(t, []string{"0", "1"}, requestedPages)
+}
+
+func TestListAllConnectionsReturnsErrors(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, err := w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Insufficient scope"}`))
+		require.NoError(t, err)
+	})
+
+	connections, err := listAllConnections(context.Background(), api)
+
+	assert.EqualError(t, err, "403 Forbidden: Insufficient scope")
+	assert.Nil(t, connections)
+}
@@ -0,0 +1,94 @@
+package connection
+
+import (
+	"sync"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+// connectionReadCacheTTL is how long a connection read by the
+// auth0_connection_client resource is reused. It only needs to
+// outlive a single Terraform operation refreshing many of them.
+const connectionReadCacheTTL = 30 * time.Second
+
+// connectionReads is shared by all the auth0_connection_client resources,
+// so that enabling many clients on the same connection doesn't issue one
+// identical read per client.
+var connectionReads = newConnectionReadCache(connectionReadCacheTTL)
+
+type connectionReadCacheKey struct {
+	api          *management.Management
+	connectionID string
+}
+
+type connectionReadCacheEntry struct {
+	done       chan struct{}
+	connection *management.Connection
+	err        error
+	expiresAt  time.Time
+}
+
+// connectionReadCache deduplicates connection reads by connection ID.
+// Concurrent reads of the same connection wait for a single API call and
+// reads within the TTL reuse its response. Failed reads are never cached.
+type connectionReadCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[connectionReadCacheKey]*connectionReadCacheEntry
+}
+
+func newConnectionReadCache(ttl time.Duration) *connectionReadCache {
+	return &connectionReadCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[connectionReadCacheKey]*connectionReadCacheEntry),
+	}
+}
+
+// Read returns the connection with the given ID, reusing a cached response if there is one.
+func (c *connectionReadCache) Read(api *management.Management, connectionID string) (*management.Connection, error) {
+	key := connectionReadCacheKey{api: api, connectionID: connectionID}
+
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	if ok && !c.isExpired(entry) {
+		c.lock.Unlock()
+
+		<-entry.done
+		return entry.connection, entry.err
+	}
+
+	entry = &connectionReadCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.lock.Unlock()
+
+	entry.connection, entry.err = api.Connection.Read(connectionID)
+
+	c.lock.Lock()
+	entry.expiresAt = c.now().Add(c.ttl)
+	if entry.err != nil && c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	c.lock.Unlock()
+
+	close(entry.done)
+
+	return entry.connection, entry.err
+}
+
+// Invalidate drops the cached response for the given connection.
+// It must be called after every write to the connection.
+func (c *connectionReadCache) Invalidate(api *management.Management, connectionID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, connectionReadCacheKey{api: api, connectionID: connectionID})
+}
+
+// isExpired must be called while holding the lock. Reads that
+// are still in flight have no expiry yet and are never expired.
+func (c *connectionReadCache) isExpired(entry *connectionReadCacheEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)
+}
@@ -0,0 +1,124 @@
+package connection
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCountingConnectionAPI(t *testing.T, reads *int32) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		atomic.AddInt32(reads, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"name":            "my-connection",
+			"strategy":        "auth0",
+			"enabled_clients": []string{"client_1"},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestConnectionReadCache(t *testing.T) {
+	t.Run("it reuses the response for repeated reads of the same connection", func(t *testing.T) {
+		var reads int32
+		api := newStubManagementAPI(t, newCountingConnectionAPI(t, &reads))
+		cache := newConnectionReadCache(time.Minute)
+
+		for i := 0; i < 3; i++ {
+			connection, err := cache.Read(api, "con_123")
+			require.NoError(t, err)
+			assert.Equal(t, "my-connection", connection.GetName())
+		}
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("it issues a single read for concurrent reads of the same connection", func(t *testing.T) {
+		var reads int32
+		api := newStubManagementAPI(t, newCountingConnectionAPI(t, &reads))
+		cache := newConnectionReadCache(time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := cache.Read(api, "con_123")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("it reads the connection again after it was invalidated", func(t *testing.T) {
+		var reads int32
+		api := newStubManagementAPI(t, newCountingConnectionAPI(t, &reads))
+		cache := newConnectionReadCache(time.Minute)
+
+		_, err := cache.Read(api, "con_123")
+		require.NoError(t, err)
+
+		cache.Invalidate(api, "con_123")
+
+		_, err = cache.Read(api, "con_123")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("it reads the connection again after the cached response expired", func(t *testing.T) {
+		var reads int32
+		api := newStubManagementAPI(t, newCountingConnectionAPI(t, &reads))
+		cache := newConnectionReadCache(time.Minute)
+
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		_, err := cache.Read(api, "con_123")
+		require.NoError(t, err)
+
+		now = now.Add(time.Minute)
+
+		_, err = cache.Read(api, "con_123")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("it does not cache failed reads", func(t *testing.T) {
+		var reads int32
+		api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&reads, 1)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"statusCode":404,"message":"The connection does not exist"}`))
+			require.NoError(t, err)
+		})
+		cache := newConnectionReadCache(time.Minute)
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.Read(api, "con_123")
+			assert.Error(t, err)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+	})
+}
@@ -2,14 +2,20 @@ package connection
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"time"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
+var errEmptyConnectionID = fmt.Errorf("ID cannot be empty")
+
 // NewResource will return a new auth0_connection resource.
 func NewResource() *schema.Resource {
 	return &schema.Resource{
@@ -17,8 +23,27 @@ func NewResource() *schema.Resource {
 		ReadContext:   readConnection,
 		UpdateContext: updateConnection,
 		DeleteContext: deleteConnection,
+		CustomizeDiff: customdiff.All(
+			validateStrategySpecificOptions,
+			validateSAMLConnectionOptions,
+			validateAppleConnectionScopes,
+			validateShowAsButton,
+			validateOktaConnectionEndpoints,
+			validateDatabaseCustomization,
+			validateSocialClientCredentials,
+			validateSMSGateway,
+			validateForwardRequestInfo,
+			validateRealms,
+			forceNewOnImmutableOptions,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importConnection,
 		},
 		Description: "With Auth0, you can define sources of users, otherwise known as connections, " +
 			"which may include identity providers (such as Google or LinkedIn), databases, or " +
@@ -44,12 +69,30 @@ func NewResource() *schema.Resource {
 func createConnection(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 
-	connection, diagnostics := expandConnection(d, api)
+	connection, diagnostics := expandConnection(ctx, d, api)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
 
-	if err := api.Connection.Create(connection); err != nil {
+	if d.Get("validate_idp_initiated_client_id").(bool) {
+		diagnostics = append(diagnostics, checkIdpInitiatedClientExists(ctx, api, connection)...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	if d.Get("validate_realms_uniqueness").(bool) {
+		diagnostics = append(diagnostics, checkRealmsNotUsedByOtherConnections(ctx, api, "", connection)...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	if d.Get("validate_display_name_uniqueness").(bool) {
+		diagnostics = append(diagnostics, checkDisplayNameNotUsedByOtherConnections(ctx, api, "", connection)...)
+	}
+
+	if err := api.Connection.Create(connection, management.Context(ctx)); err != nil {
 		diagnostics = append(diagnostics, diag.FromErr(err)...)
 		return diagnostics
 	}
@@ -63,9 +106,9 @@ func createConnection(ctx context.Context, d *schema.ResourceData, m interface{}
 func readConnection(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 
-	connection, err := api.Connection.Read(d.Id())
+	connection, err := api.Connection.Read(d.Id(), management.Context(ctx))
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -77,6 +120,11 @@ func readConnection(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diags
 	}
 
+	signingKeys, err := flattenConnectionSigningKeys(connection.Options)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	result := multierror.Append(
 		d.Set("name", connection.GetName()),
 		d.Set("display_name", connection.GetDisplayName()),
@@ -85,7 +133,8 @@ func readConnection(ctx context.Context, d *schema.ResourceData, m interface{})
 		d.Set("options", connectionOptions),
 		d.Set("realms", connection.GetRealms()),
 		d.Set("metadata", connection.GetMetadata()),
-		d.Set("enabled_clients", connection.GetEnabledClients()),
+		d.Set("enabled_clients", flattenConnectionEnabledClients(connection.GetEnabledClients())),
+		d.Set("signing_keys", signingKeys),
 	)
 
 	switch connection.GetStrategy() {
@@ -105,12 +154,34 @@ func readConnection(ctx context.Context, d *schema.ResourceData, m interface{})
 func updateConnection(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 
-	connection, diagnostics := expandConnection(d, api)
+	connection, diagnostics := expandConnection(ctx, d, api)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
 
-	if err := api.Connection.Update(d.Id(), connection); err != nil {
+	if d.Get("validate_idp_initiated_client_id").(bool) {
+		diagnostics = append(diagnostics, checkIdpInitiatedClientExists(ctx, api, connection)...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	if d.Get("validate_realms_uniqueness").(bool) {
+		diagnostics = append(diagnostics, checkRealmsNotUsedByOtherConnections(ctx, api, d.Id(), connection)...)
+		if diagnostics.HasError() {
+			return diagnostics
+		}
+	}
+
+	if d.Get("validate_display_name_uniqueness").(bool) {
+		diagnostics = append(diagnostics, checkDisplayNameNotUsedByOtherConnections(ctx, api, d.Id(), connection)...)
+	}
+
+	diagnostics = append(diagnostics, checkEnabledClientsOverlap(d)...)
+
+	err := api.Connection.Update(d.Id(), connection, management.Context(ctx))
+	connectionReads.Invalidate(api, d.Id())
+	if err != nil {
 		diagnostics = append(diagnostics, diag.FromErr(err)...)
 		return diagnostics
 	}
@@ -122,8 +193,10 @@ func updateConnection(ctx context.Context, d *schema.ResourceData, m interface{}
 func deleteConnection(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 
-	if err := api.Connection.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+	err := api.Connection.Delete(d.Id(), management.Context(ctx))
+	connectionReads.Invalidate(api, d.Id())
+	if err != nil {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
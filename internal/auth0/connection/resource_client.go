@@ -3,7 +3,6 @@ package connection
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -11,12 +10,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/mutex"
 )
 
 var (
-	errEmptyConnectionClientID         = fmt.Errorf("ID cannot be empty")
-	errInvalidConnectionClientIDFormat = fmt.Errorf("ID must be formated as <connectionID>:<clientID>")
+	errEmptyConnectionClientID           = fmt.Errorf("ID cannot be empty")
+	errInvalidConnectionClientIDFormat   = fmt.Errorf("ID must be formated as <connectionID>:<clientID>")
+	errConnectionClientIDWithoutClientID = fmt.Errorf(
+		"ID must be formated as <connectionID>:<clientID>, use the auth0_connection_clients " +
+			"resource to import all the enabled clients of a connection at once",
+	)
 )
 
 // NewClientResource will return a new auth0_connection_client resource.
@@ -64,18 +68,8 @@ func createConnectionClient(ctx context.Context, data *schema.ResourceData, meta
 	mutex.Global.Lock(connectionID)
 	defer mutex.Global.Unlock(connectionID)
 
-	connection, err := api.Connection.Read(connectionID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	clientID := data.Get("client_id").(string)
-	enabledClients := append(connection.GetEnabledClients(), clientID)
-
-	if err := api.Connection.Update(
-		connectionID,
-		&management.Connection{EnabledClients: &enabledClients},
-	); err != nil {
+	if err := updateEnabledClients(ctx, api, connectionID, []string{clientID}, true); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -90,27 +84,23 @@ func readConnectionClient(_ context.Context, data *schema.ResourceData, meta int
 	connectionID := data.Get("connection_id").(string)
 	clientID := data.Get("client_id").(string)
 
-	connection, err := api.Connection.Read(connectionID)
+	connection, err := connectionReads.Read(api, connectionID)
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			data.SetId("")
 			return nil
 		}
 		return diag.FromErr(err)
 	}
 
-	found := false
-	for _, enabledClientID := range connection.GetEnabledClients() {
-		if enabledClientID == clientID {
-			found = true
-		}
-	}
-	if !found {
+	if !containsEnabledClient(connection.GetEnabledClients(), clientID) {
 		data.SetId("")
 		return nil
 	}
 
 	result := multierror.Append(
+		data.Set("connection_id", connection.GetID()),
+		data.Set("client_id", clientID),
 		data.Set("name", connection.GetName()),
 		data.Set("strategy", connection.GetStrategy()),
 	)
@@ -118,37 +108,16 @@ func readConnectionClient(_ context.Context, data *schema.ResourceData, meta int
 	return diag.FromErr(result.ErrorOrNil())
 }
 
-func deleteConnectionClient(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func deleteConnectionClient(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	api := meta.(*management.Management)
 
 	connectionID := data.Get("connection_id").(string)
-
-	mutex.Global.Lock(connectionID)
-	defer mutex.Global.Unlock(connectionID)
-
-	connection, err := api.Connection.Read(connectionID)
-	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
-			data.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
-	}
-
 	clientID := data.Get("client_id").(string)
-	var enabledClients []string
-	for _, enabledClientID := range connection.GetEnabledClients() {
-		if enabledClientID == clientID {
-			continue
-		}
-		enabledClients = append(enabledClients, enabledClientID)
-	}
 
-	if err := api.Connection.Update(
-		connectionID,
-		&management.Connection{EnabledClients: &enabledClients},
-	); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+	// Removals are coalesced with the other ones targeting the same
+	// connection, which takes care of holding the connection lock.
+	if err := enabledClientsRemovals.Remove(ctx, api, connectionID, clientID); err != nil {
+		if internalError.IsStatusNotFound(err) {
 			data.SetId("")
 			return nil
 		}
@@ -20,11 +20,11 @@ func importConnectionClient(
 	}
 
 	if !strings.Contains(rawID, ":") {
-		return nil, errInvalidConnectionClientIDFormat
+		return nil, errConnectionClientIDWithoutClientID
 	}
 
 	idPair := strings.Split(rawID, ":")
-	if len(idPair) != 2 {
+	if len(idPair) != 2 || idPair[0] == "" || idPair[1] == "" {
 		return nil, errInvalidConnectionClientIDFormat
 	}
 
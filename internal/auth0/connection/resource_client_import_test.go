@@ -31,13 +31,23 @@ func TestImportConnectionClient(t *testing.T) {
 		{
 			testName:      "it fails when the given ID does not have \":\" as a separator",
 			givenID:       "client_1234conn_5678",
-			expectedError: fmt.Errorf("ID must be formated as <connectionID>:<clientID>"),
+			expectedError: errConnectionClientIDWithoutClientID,
 		},
 		{
 			testName:      "it fails when the given ID has too many separators",
 			givenID:       "client_1234:conn_5678:",
 			expectedError: fmt.Errorf("ID must be formated as <connectionID>:<clientID>"),
 		},
+		{
+			testName:      "it fails when the given ID has an empty connection ID",
+			givenID:       ":client_1234",
+			expectedError: fmt.Errorf("ID must be formated as <connectionID>:<clientID>"),
+		},
+		{
+			testName:      "it fails when the given ID has an empty client ID",
+			givenID:       "conn_5678:",
+			expectedError: fmt.Errorf("ID must be formated as <connectionID>:<clientID>"),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,156 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
+	"github.com/auth0/terraform-provider-auth0/internal/mutex"
+	"github.com/auth0/terraform-provider-auth0/internal/value"
+)
+
+var (
+	errEmptyConnectionClientsID   = fmt.Errorf("ID cannot be empty")
+	errInvalidConnectionClientsID = fmt.Errorf(
+		"ID must be the ID of the connection, use the auth0_connection_client " +
+			"resource to import a single <connectionID>:<clientID> association",
+	)
+)
+
+// NewClientsResource will return a new auth0_connection_clients resource.
+func NewClientsResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the connection on which to enable the clients.",
+			},
+			"enabled_clients": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the clients for which the connection is enabled.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the connection on which to enable the clients.",
+			},
+			"strategy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The strategy of the connection on which to enable the clients.",
+			},
+		},
+		CreateContext: createConnectionClients,
+		ReadContext:   readConnectionClients,
+		UpdateContext: updateConnectionClients,
+		DeleteContext: deleteConnectionClients,
+		Importer: &schema.ResourceImporter{
+			StateContext: importConnectionClients,
+		},
+		Description: "With this resource, you can manage all of the enabled clients on a connection. " +
+			"This resource is authoritative and will remove any client enabled outside of it. " +
+			"It conflicts with the `auth0_connection_client` resource and with the `enabled_clients` " +
+			"attribute of the `auth0_connection` resource, so they should not be used together " +
+			"to manage the same connection.",
+	}
+}
+
+func createConnectionClients(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	connectionID := data.Get("connection_id").(string)
+
+	mutex.Global.Lock(connectionID)
+	defer mutex.Global.Unlock(connectionID)
+
+	enabledClients := value.Strings(data.GetRawConfig().GetAttr("enabled_clients"))
+	err := api.Connection.Update(
+		connectionID,
+		&management.Connection{EnabledClients: enabledClients},
+	)
+	connectionReads.Invalidate(api, connectionID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(connectionID)
+
+	return readConnectionClients(ctx, data, meta)
+}
+
+func readConnectionClients(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	connection, err := api.Connection.Read(data.Id())
+	if err != nil {
+		if internalError.IsStatusNotFound(err) {
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	result := multierror.Append(
+		data.Set("connection_id", connection.GetID()),
+		data.Set("enabled_clients", flattenConnectionEnabledClients(connection.GetEnabledClients())),
+		data.Set("name", connection.GetName()),
+		data.Set("strategy", connection.GetStrategy()),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func updateConnectionClients(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	mutex.Global.Lock(data.Id())
+	defer mutex.Global.Unlock(data.Id())
+
+	enabledClients := value.Strings(data.GetRawConfig().GetAttr("enabled_clients"))
+	err := api.Connection.Update(
+		data.Id(),
+		&management.Connection{EnabledClients: enabledClients},
+	)
+	connectionReads.Invalidate(api, data.Id())
+	if err != nil {
+		if internalError.IsStatusNotFound(err) {
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return readConnectionClients(ctx, data, meta)
+}
+
+func deleteConnectionClients(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*management.Management)
+
+	mutex.Global.Lock(data.Id())
+	defer mutex.Global.Unlock(data.Id())
+
+	err := api.Connection.Update(
+		data.Id(),
+		&management.Connection{EnabledClients: &[]string{}},
+	)
+	connectionReads.Invalidate(api, data.Id())
+	if err != nil {
+		if internalError.IsStatusNotFound(err) {
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
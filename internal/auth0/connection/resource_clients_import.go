@@ -0,0 +1,29 @@
+package connection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importConnectionClients imports all the enabled clients of a connection
+// from its ID, as the whole set is then populated by the read.
+func importConnectionClients(
+	_ context.Context,
+	data *schema.ResourceData,
+	_ interface{},
+) ([]*schema.ResourceData, error) {
+	connectionID := data.Id()
+	if connectionID == "" {
+		return nil, errEmptyConnectionClientsID
+	}
+
+	if strings.Contains(connectionID, ":") {
+		return nil, errInvalidConnectionClientsID
+	}
+
+	err := data.Set("connection_id", connectionID)
+
+	return []*schema.ResourceData{data}, err
+}
@@ -0,0 +1,90 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportConnectionClients(t *testing.T) {
+	var testCases = []struct {
+		testName      string
+		givenID       string
+		expectedError error
+	}{
+		{
+			testName: "it sets the connection ID",
+			givenID:  "con_123",
+		},
+		{
+			testName:      "it fails when the given ID is empty",
+			givenID:       "",
+			expectedError: errEmptyConnectionClientsID,
+		},
+		{
+			testName:      "it fails when the given ID is a single association",
+			givenID:       "con_123:client_1",
+			expectedError: errInvalidConnectionClientsID,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, NewClientsResource().Schema, nil)
+			data.SetId(testCase.givenID)
+
+			actualData, err := importConnectionClients(context.Background(), data, nil)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				assert.Nil(t, actualData)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.givenID, actualData[0].Id())
+			assert.Equal(t, testCase.givenID, actualData[0].Get("connection_id"))
+		})
+	}
+}
+
+func TestImportConnectionClientsPopulatesAllEnabledClients(t *testing.T) {
+	enabledClients := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		enabledClients = append(enabledClients, fmt.Sprintf("client_%d", i))
+	}
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"name":            "Username-Password-Authentication",
+			"strategy":        "auth0",
+			"enabled_clients": enabledClients,
+		})
+		require.NoError(t, err)
+	})
+
+	data := schema.TestResourceDataRaw(t, NewClientsResource().Schema, nil)
+	data.SetId("con_123")
+
+	importedData, err := importConnectionClients(context.Background(), data, api)
+	require.NoError(t, err)
+
+	diags := readConnectionClients(context.Background(), importedData[0], api)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, "con_123", importedData[0].Get("connection_id"))
+	assert.Equal(t, "Username-Password-Authentication", importedData[0].Get("name"))
+	assert.Equal(t, "auth0", importedData[0].Get("strategy"))
+	assert.Equal(t, len(enabledClients), importedData[0].Get("enabled_clients").(*schema.Set).Len())
+}
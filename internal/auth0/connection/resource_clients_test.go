@@ -0,0 +1,139 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateConnectionClients(t *testing.T) {
+	enabledClients := []string{"client_1"}
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			var body struct {
+				EnabledClients []string `json:"enabled_clients"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			enabledClients = body.EnabledClients
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":              "con_123",
+			"name":            "Username-Password-Authentication",
+			"strategy":        "auth0",
+			"enabled_clients": enabledClients,
+		})
+		require.NoError(t, err)
+	})
+
+	resource := NewClientsResource()
+	data := resource.Data(&terraform.InstanceState{
+		Attributes: map[string]string{"connection_id": "con_123"},
+		// The enabled clients are read from the raw config,
+		// which Terraform would otherwise send alongside the plan.
+		RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+			"connection_id":   cty.StringVal("con_123"),
+			"enabled_clients": toStringSetVal([]string{"client_2", "client_3"}),
+		}),
+	})
+
+	diags := createConnectionClients(context.Background(), data, api)
+	require.False(t, diags.HasError(), "Expected no errors, got %v", diags)
+
+	assert.Equal(t, "con_123", data.Id())
+	assert.Equal(t, "Username-Password-Authentication", data.Get("name"))
+	assert.ElementsMatch(t, []string{"client_2", "client_3"}, enabledClients)
+	assert.ElementsMatch(
+		t,
+		[]interface{}{"client_2", "client_3"},
+		data.Get("enabled_clients").(*schema.Set).List(),
+	)
+}
+
+func TestReadConnectionClients(t *testing.T) {
+	t.Run("it detects clients changed outside of terraform", func(t *testing.T) {
+		api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			err := json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":              "con_123",
+				"name":            "Username-Password-Authentication",
+				"strategy":        "auth0",
+				"enabled_clients": []string{"client_1", "client_3"},
+			})
+			require.NoError(t, err)
+		})
+
+		data := schema.TestResourceDataRaw(t, NewClientsResource().Schema, map[string]interface{}{
+			"connection_id":   "con_123",
+			"enabled_clients": []interface{}{"client_1", "client_2"},
+		})
+		data.SetId("con_123")
+
+		diags := readConnectionClients(context.Background(), data, api)
+		require.False(t, diags.HasError())
+
+		assert.Equal(t, "con_123", data.Id())
+		assert.Equal(t, "con_123", data.Get("connection_id"))
+		assert.Equal(t, "Username-Password-Authentication", data.Get("name"))
+		assert.Equal(t, "auth0", data.Get("strategy"))
+		assert.ElementsMatch(
+			t,
+			[]interface{}{"client_1", "client_3"},
+			data.Get("enabled_clients").(*schema.Set).List(),
+		)
+	})
+
+	t.Run("it removes the resource from state when the connection is gone", func(t *testing.T) {
+		api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The connection does not exist"}`))
+			require.NoError(t, err)
+		})
+
+		data := schema.TestResourceDataRaw(t, NewClientsResource().Schema, nil)
+		data.SetId("con_123")
+
+		diags := readConnectionClients(context.Background(), data, api)
+		require.False(t, diags.HasError())
+
+		assert.Equal(t, "", data.Id())
+	})
+}
+
+func TestDeleteConnectionClients(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v2/connections/con_123", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []interface{}{}, body["enabled_clients"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"id":"con_123","enabled_clients":[]}`))
+		require.NoError(t, err)
+	})
+
+	data := schema.TestResourceDataRaw(t, NewClientsResource().Schema, nil)
+	data.SetId("con_123")
+
+	diags := deleteConnectionClients(context.Background(), data, api)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, "", data.Id())
+}
@@ -0,0 +1,61 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const connectionIDPrefix = "con_"
+
+// importConnection allows importing a connection either by its ID or by its name.
+func importConnection(
+	ctx context.Context,
+	data *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	rawID := data.Id()
+	if rawID == "" {
+		return nil, errEmptyConnectionID
+	}
+
+	if strings.HasPrefix(rawID, connectionIDPrefix) {
+		return []*schema.ResourceData{data}, nil
+	}
+
+	api := meta.(*management.Management)
+
+	connections, err := listAllConnections(
+		ctx,
+		api,
+		management.IncludeFields("id", "name"),
+		management.Parameter("name", rawID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidateIDs []string
+	for _, connection := range connections {
+		if connection.GetName() == rawID {
+			candidateIDs = append(candidateIDs, connection.GetID())
+		}
+	}
+
+	switch len(candidateIDs) {
+	case 0:
+		return nil, fmt.Errorf("no connection found with name %q", rawID)
+	case 1:
+		data.SetId(candidateIDs[0])
+		return []*schema.ResourceData{data}, nil
+	default:
+		return nil, fmt.Errorf(
+			"found multiple connections with name %q, import one of them by ID instead: %s",
+			rawID,
+			strings.Join(candidateIDs, ", "),
+		)
+	}
+}
@@ -0,0 +1,114 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportConnection(t *testing.T) {
+	var testCases = []struct {
+		testName         string
+		givenID          string
+		givenConnections []map[string]string
+		expectedID       string
+		expectedError    error
+	}{
+		{
+			testName:   "it keeps the ID when importing by ID",
+			givenID:    "con_1234",
+			expectedID: "con_1234",
+		},
+		{
+			testName: "it resolves the ID when importing by name",
+			givenID:  "my-connection",
+			givenConnections: []map[string]string{
+				{"id": "con_1234", "name": "my-connection"},
+			},
+			expectedID: "con_1234",
+		},
+		{
+			testName:      "it fails when the given ID is empty",
+			givenID:       "",
+			expectedError: fmt.Errorf("ID cannot be empty"),
+		},
+		{
+			testName:      "it fails when no connection matches the given name",
+			givenID:       "my-connection",
+			expectedError: fmt.Errorf("no connection found with name \"my-connection\""),
+		},
+		{
+			testName: "it fails when multiple connections match the given name",
+			givenID:  "my-connection",
+			givenConnections: []map[string]string{
+				{"id": "con_1234", "name": "my-connection"},
+				{"id": "con_5678", "name": "my-connection"},
+			},
+			expectedError: fmt.Errorf(
+				"found multiple connections with name \"my-connection\", " +
+					"import one of them by ID instead: con_1234, con_5678",
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/connections", r.URL.Path)
+				assert.Equal(t, testCase.givenID, r.URL.Query().Get("name"))
+
+				writeStubConnectionList(t, w, testCase.givenConnections)
+			})
+
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, nil)
+			data.SetId(testCase.givenID)
+
+			actualData, err := importConnection(context.Background(), data, api)
+
+			if testCase.expectedError != nil {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+				assert.Nil(t, actualData)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedID, actualData[0].Id())
+		})
+	}
+}
+
+// newStubManagementAPI returns a Management API client that
+// sends all of its requests to the given handler.
+func newStubManagementAPI(t *testing.T, handler http.HandlerFunc) *management.Management {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	return api
+}
+
+func writeStubConnectionList(t *testing.T, w http.ResponseWriter, connections []map[string]string) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"start":       0,
+		"limit":       50,
+		"length":      len(connections),
+		"total":       len(connections),
+		"connections": connections,
+	})
+	require.NoError(t, err)
+}
@@ -53,6 +53,7 @@ func TestAccConnection(t *testing.T) {
 					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.upstream_params", ""),
 					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.enable_script_context", "true"),
 					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.enabled_database_customization", "true"),
+					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.custom_scripts.login", "myLoginFunction"),
 					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.set_user_root_attributes", "on_first_login"),
 					resource.TestCheckResourceAttr("auth0_connection.my_connection", "options.0.non_persistent_attrs.#", "0"),
 				),
@@ -143,6 +144,7 @@ resource "auth0_connection" "my_connection" {
 		disable_signup = false
 		requires_username = true
 		custom_scripts = {
+			login = "myLoginFunction"
 			get_user = "myFunction"
 		}
 		configuration = {
@@ -157,6 +159,229 @@ resource "auth0_connection" "my_connection" {
 }
 `
 
+func TestAccConnectionDatabaseCustomizationValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-Database-Customization-Validation"
+	strategy = "auth0"
+	options {
+		enabled_database_customization = true
+		custom_scripts = {
+			get_user = "myFunction"
+		}
+	}
+}`,
+				ExpectError: regexp.MustCompile("a `login` script must be specified"),
+			},
+		},
+	})
+}
+
+func TestAccConnectionSocialClientCredentialsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "google_oauth2" {
+	name = "Acceptance-Test-Google-OAuth2-Client-Credentials-Validation"
+	strategy = "google-oauth2"
+	options {
+		client_id = "client_id"
+	}
+}`,
+				ExpectError: regexp.MustCompile("`options.0.client_secret` must also be specified when `client_id` is set"),
+			},
+		},
+	})
+}
+
+func TestAccConnectionSAMLSignRequestAlgorithmsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "samlp" {
+	name = "Acceptance-Test-SAML-Sign-Request-Algorithms-Validation"
+	strategy = "samlp"
+	options {
+		metadata_url = "https://saml.provider/metadata.xml"
+		sign_saml_request = true
+		signature_algorithm = "rsa-sha512"
+		digest_algorithm = "sha256"
+	}
+}`,
+				ExpectError: regexp.MustCompile(`expected options.0.signature_algorithm to be one of \[rsa-sha256 rsa-sha1\]`),
+			},
+			{
+				Config: `
+resource "auth0_connection" "samlp" {
+	name = "Acceptance-Test-SAML-Sign-Request-Algorithms-Validation"
+	strategy = "samlp"
+	options {
+		metadata_url = "https://saml.provider/metadata.xml"
+		sign_saml_request = true
+		signature_algorithm = "rsa-sha256"
+	}
+}`,
+				ExpectError: regexp.MustCompile("`options.0.signature_algorithm` and `options.0.digest_algorithm` must be specified"),
+			},
+		},
+	})
+}
+
+func TestAccConnectionCustomSMSGatewayValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "sms" {
+	name = "Acceptance-Test-Custom-SMS-Gateway-Validation"
+	strategy = "sms"
+	options {
+		provider = "sms_gateway"
+		gateway_url = "https://somewhere.com/sms-gateway"
+		gateway_authentication {
+			method = "basic"
+		}
+	}
+}`,
+				ExpectError: regexp.MustCompile(`expected options.0.gateway_authentication.0.method to be one of \[bearer\], got basic`),
+			},
+			{
+				Config: `
+resource "auth0_connection" "sms" {
+	name = "Acceptance-Test-Custom-SMS-Gateway-Validation"
+	strategy = "sms"
+	options {
+		provider = "sms_gateway"
+	}
+}`,
+				ExpectError: regexp.MustCompile("`options.0.gateway_url` must be specified"),
+			},
+			{
+				Config: `
+resource "auth0_connection" "sms" {
+	name = "Acceptance-Test-Custom-SMS-Gateway-Validation"
+	strategy = "sms"
+	options {
+		provider = "sms_gateway"
+		gateway_url = "https://somewhere.com/sms-gateway"
+		gateway_authentication {
+			method = "bearer"
+			subject = "test.us.auth0.com:sms"
+		}
+	}
+}`,
+				ExpectError: regexp.MustCompile(`missing: audience, secret`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionForwardRequestInfoValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "sms" {
+	name = "Acceptance-Test-Forward-Request-Info-Validation"
+	strategy = "sms"
+	options {
+		twilio_sid = "ABC123"
+		twilio_token = "DEF456"
+		forward_request_info = true
+	}
+}`,
+				ExpectError: regexp.MustCompile("`forward_request_info` can only be enabled on SMS connections"),
+			},
+		},
+	})
+}
+
+func TestAccConnectionURLOptionsValidation(t *testing.T) {
+	var testCases = []struct {
+		strategy string
+		option   string
+	}{
+		{strategy: "oidc", option: "icon_url"},
+		{strategy: "oidc", option: "discovery_url"},
+		{strategy: "oidc", option: "jwks_uri"},
+		{strategy: "oidc", option: "issuer"},
+		{strategy: "oidc", option: "token_endpoint"},
+		{strategy: "oidc", option: "userinfo_endpoint"},
+		{strategy: "oidc", option: "authorization_endpoint"},
+		{strategy: "salesforce-community", option: "community_base_url"},
+		{strategy: "adfs", option: "adfs_server"},
+		{strategy: "samlp", option: "metadata_url"},
+	}
+
+	var steps []resource.TestStep
+	for _, testCase := range testCases {
+		steps = append(steps, resource.TestStep{
+			Config: fmt.Sprintf(`
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-URL-Options-Validation"
+	strategy = "%s"
+	options {
+		%s = "not a url"
+	}
+}`, testCase.strategy, testCase.option),
+			ExpectError: regexp.MustCompile(
+				fmt.Sprintf(`expected "options.0.%s" to have a host, got not a url`, testCase.option),
+			),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps:             steps,
+	})
+}
+
+func TestAccConnectionRealmsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "ad" {
+	name = "Acceptance-Test-Realms-Validation"
+	strategy = "ad"
+	realms = ["acme.com", "acme.org", "acme.com"]
+}`,
+				ExpectError: regexp.MustCompile(`the realm "acme.com" is listed more than once`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionMetadataKeyValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-Metadata-Key-Validation"
+	strategy = "auth0"
+	metadata = {
+		"tier.name" = "gold"
+	}
+}`,
+				ExpectError: regexp.MustCompile(`The metadata key "tier.name" must not contain any of the`),
+			},
+		},
+	})
+}
+
 func TestAccConnectionAD(t *testing.T) {
 	acctest.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
@@ -207,6 +432,87 @@ resource "auth0_connection" "ad" {
 }
 `
 
+func TestAccConnectionADIPsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "ad" {
+	name = "Acceptance-Test-AD-IPs-Validation"
+	strategy = "ad"
+	options {
+		ips = ["192.168.1.1", "10.0.0.0/8", "10.0.0.0/33"]
+	}
+}`,
+				ExpectError: regexp.MustCompile(`to be a valid IP address or CIDR range, got: 10.0.0.0/33`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionDomainAliasesValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "ad" {
+	name = "Acceptance-Test-AD-Domain-Aliases-Validation"
+	strategy = "ad"
+	options {
+		domain_aliases = ["example.com", "https://api.example.com"]
+	}
+}`,
+				ExpectError: regexp.MustCompile(`to be a valid domain name, got: https://api.example.com`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionUpstreamParamsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "google_oauth2" {
+	name = "Acceptance-Test-Google-OAuth2-Upstream-Params-Validation"
+	strategy = "google-oauth2"
+	options {
+		upstream_params = jsonencode({
+			"screen_name": "login_hint"
+		})
+	}
+}`,
+				ExpectError: regexp.MustCompile(`parameter "screen_name" is invalid`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionSAMLFieldsMapValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "samlp" {
+	name = "Acceptance-Test-SAML-Fields-Map-Validation"
+	strategy = "samlp"
+	options {
+		sign_in_endpoint = "https://saml.provider/sign_in"
+		fields_map = jsonencode({
+			"name": { "first": "given_name" }
+		})
+	}
+}`,
+				ExpectError: regexp.MustCompile(`attribute "name" must map to a string or an array of strings`),
+			},
+		},
+	})
+}
+
 func TestAccConnectionAzureAD(t *testing.T) {
 	acctest.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
@@ -519,6 +825,26 @@ resource "auth0_connection" "oidc" {
 }
 `
 
+func TestAccConnectionOrderedScopeConflictsWithScopes(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-Ordered-Scope-Validation"
+	strategy = "oidc"
+	options {
+		scope  = [ "openid", "email" ]
+		scopes = [ "openid", "email" ]
+	}
+}`,
+				ExpectError: regexp.MustCompile(`"options.0.scope": conflicts with options.0.scopes`),
+			},
+		},
+	})
+}
+
 func TestAccConnectionOkta(t *testing.T) {
 	acctest.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
@@ -630,6 +956,29 @@ resource "auth0_connection" "okta" {
 }
 `
 
+func TestAccConnectionOktaEndpointsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "okta" {
+	name = "Acceptance-Test-Okta-Endpoints-Validation"
+	strategy = "okta"
+	options {
+		client_id = "123456"
+		client_secret = "123456"
+		domain = "domain.okta.com"
+		issuer = "https://domain.okta.com"
+		token_endpoint = "https://domain.okta.com/oauth2/v1/token"
+	}
+}`,
+				ExpectError: regexp.MustCompile(`missing: authorization_endpoint, jwks_uri, userinfo_endpoint`),
+			},
+		},
+	})
+}
+
 func TestAccConnectionOAuth2(t *testing.T) {
 	acctest.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
@@ -1134,6 +1483,28 @@ resource "auth0_connection" "facebook" {
 }
 `
 
+func TestAccConnectionAppleScopesValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "apple" {
+	name = "Acceptance-Test-Apple-Scopes-Validation"
+	strategy = "apple"
+	options {
+		client_id = "client_id"
+		scopes = ["email", "openid"]
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					`options.0.scopes: "openid" is not supported by connections with the "apple" strategy`,
+				),
+			},
+		},
+	})
+}
+
 func TestAccConnectionApple(t *testing.T) {
 	acctest.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
@@ -1692,6 +2063,74 @@ func TestAccConnectionTwitter(t *testing.T) {
 	})
 }
 
+func TestAccConnectionSAMLOptionsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-SAML-Validation"
+	strategy = "samlp"
+	options {
+		sign_in_endpoint = "https://saml.provider/sign_in"
+	}
+}`,
+				ExpectError: regexp.MustCompile("options.0.sign_in_endpoint: one of `options.0.signing_cert`"),
+			},
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-SAML-Validation"
+	strategy = "samlp"
+	options {
+		protocol_binding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	}
+}`,
+				ExpectError: regexp.MustCompile("options.0.protocol_binding: `options.0.sign_in_endpoint` must be specified"),
+			},
+		},
+	})
+}
+
+func TestAccConnectionShowAsButtonValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-Show-As-Button-Validation"
+	strategy = "auth0"
+	show_as_button = true
+}`,
+				ExpectError: regexp.MustCompile(`show_as_button: this attribute is only supported by enterprise connections`),
+			},
+		},
+	})
+}
+
+func TestAccConnectionStrategySpecificOptionsValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_connection" "my_connection" {
+	name = "Acceptance-Test-Strategy-Validation"
+	strategy = "google-oauth2"
+	options {
+		twilio_sid = "ABC"
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					`options.0.twilio_sid: this option is only supported by connections with the "sms" strategy`,
+				),
+			},
+		},
+	})
+}
+
 const testAccConnectionTwitterConfig = `
 resource "auth0_connection" "twitter" {
 	name = "Acceptance-Test-Twitter-{{.testName}}"
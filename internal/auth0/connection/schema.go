@@ -3,12 +3,16 @@ package connection
 import (
 	"context"
 	"log"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	internalSchema "github.com/auth0/terraform-provider-auth0/internal/schema"
+	internalValidation "github.com/auth0/terraform-provider-auth0/internal/validation"
 )
 
 var resourceSchema = map[string]*schema.Schema{
@@ -55,9 +59,11 @@ var resourceSchema = map[string]*schema.Schema{
 		Type:             schema.TypeMap,
 		Elem:             &schema.Schema{Type: schema.TypeString},
 		Optional:         true,
-		ValidateDiagFunc: validation.MapKeyLenBetween(0, 10),
+		ValidateDiagFunc: validateConnectionMetadata(),
 		Description: "Metadata associated with the connection, in the form of a map of string values " +
-			"(max 255 chars). Maximum of 10 metadata properties allowed.",
+			"(max 255 chars). Maximum of 10 metadata properties allowed. Keys must not contain `.` or `$` " +
+			"and are limited to 255 chars. To store structured data, " +
+			"suffix the key with `_json` and set the value with `jsonencode()`: it will be validated as JSON.",
 	},
 	"options": {
 		Type:        schema.TypeList,
@@ -68,9 +74,10 @@ var resourceSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"validation": {
-					Type:        schema.TypeList,
-					MaxItems:    1,
-					Description: "Validation of the minimum and maximum values allowed for a user to have as username.",
+					Type:     schema.TypeList,
+					MaxItems: 1,
+					Description: "Validation of the minimum and maximum values allowed for a user to have as username. " +
+						"Other validation settings of the connection are left untouched.",
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"username": {
@@ -199,13 +206,15 @@ var resourceSchema = map[string]*schema.Schema{
 						"(warning: cannot be disabled once enabled).",
 				},
 				"enabled_database_customization": {
-					Type:        schema.TypeBool,
-					Optional:    true,
-					Description: "Set to `true` to use a legacy user store.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Description: "Set to `true` to use a legacy user store. " +
+						"Requires a `login` script in `custom_scripts`.",
 				},
 				"brute_force_protection": {
 					Type:     schema.TypeBool,
 					Optional: true,
+					Computed: true,
 					Description: "Indicates whether to enable brute force protection, which will limit " +
 						"the number of signups and failed logins from a suspicious IP address.",
 				},
@@ -216,9 +225,11 @@ var resourceSchema = map[string]*schema.Schema{
 						"those users to the Auth0 user store.",
 				},
 				"disable_signup": {
-					Type:        schema.TypeBool,
-					Optional:    true,
-					Description: "Indicates whether to allow user sign-ups to your application.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+					Description: "Indicates whether to allow user sign-ups to your application. " +
+						"If not set, the value defined on the connection is kept.",
 				},
 				"requires_username": {
 					Type:     schema.TypeBool,
@@ -227,16 +238,20 @@ var resourceSchema = map[string]*schema.Schema{
 						"in addition to an email address.",
 				},
 				"custom_scripts": {
-					Type:        schema.TypeMap,
-					Elem:        &schema.Schema{Type: schema.TypeString},
-					Optional:    true,
-					Description: "A map of scripts used to integrate with a custom database.",
+					Type:             schema.TypeMap,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					Optional:         true,
+					DiffSuppressFunc: suppressScriptFormattingDiff,
+					Description: "A map of scripts used to integrate with a custom database. " +
+						"Differences in trailing whitespace and newlines are ignored.",
 				},
 				"scripts": {
-					Type:        schema.TypeMap,
-					Elem:        &schema.Schema{Type: schema.TypeString},
-					Optional:    true,
-					Description: "A map of scripts used for an OAuth connection. Only accepts a `fetchUserProfile` script.",
+					Type:             schema.TypeMap,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					Optional:         true,
+					DiffSuppressFunc: suppressScriptFormattingDiff,
+					Description: "A map of scripts used for an OAuth connection. Only accepts a `fetchUserProfile` script. " +
+						"Differences in trailing whitespace and newlines are ignored.",
 				},
 				"configuration": {
 					Type:      schema.TypeMap,
@@ -247,15 +262,17 @@ var resourceSchema = map[string]*schema.Schema{
 						"for the `custom_script`.",
 				},
 				"client_id": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "The strategy's client ID.",
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "The strategy's client ID. On social connections such as `google-oauth2`, " +
+						"it must be set together with `client_secret`, or both left unset to use the Auth0 developer keys.",
 				},
 				"client_secret": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Sensitive:   true,
-					Description: "The strategy's client secret.",
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Description: "The strategy's client secret. On connections with the `apple` strategy, " +
+						"this is the private key (`.p8` file contents) used to sign the client secret.",
 				},
 				"allowed_audiences": {
 					Type:        schema.TypeSet,
@@ -282,15 +299,21 @@ var resourceSchema = map[string]*schema.Schema{
 				"domain_aliases": {
 					Type:     schema.TypeSet,
 					Computed: true,
-					Elem:     &schema.Schema{Type: schema.TypeString},
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateDomainName,
+					},
 					Optional: true,
 					Description: "List of the domains that can be authenticated using the identity provider. " +
-						"Only needed for Identifier First authentication flows.",
+						"Only needed for Identifier First authentication flows. Each entry must be a valid domain name.",
 				},
 				"max_groups_to_retrieve": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Maximum number of groups to retrieve.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressEquivalentIntegerDiff,
+					Description: "Maximum number of groups to retrieve. Although stored as a string, " +
+						"values that represent the same integer (for example `100` and `\"0100\"`) " +
+						"are considered equal and do not produce a diff.",
 				},
 				"tenant_domain": {
 					Type:        schema.TypeString,
@@ -314,9 +337,10 @@ var resourceSchema = map[string]*schema.Schema{
 						"Typically enabled if you're using this for a multi-tenant application in Azure AD.",
 				},
 				"icon_url": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Icon URL.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Description:  "Icon URL.",
 				},
 				"identity_api": {
 					Type:     schema.TypeString,
@@ -326,14 +350,18 @@ var resourceSchema = map[string]*schema.Schema{
 						"azure-active-directory-v1.0",
 					}, false),
 					Description: "Azure AD Identity API. Available options are: " +
-						"`microsoft-identity-platform-v2.0` or `azure-active-directory-v1.0`.",
+						"`microsoft-identity-platform-v2.0` or `azure-active-directory-v1.0`. " +
+						"Changing it on a `waad` connection forces a new resource to be created.",
 				},
 				"ips": {
-					Type:        schema.TypeSet,
-					Elem:        &schema.Schema{Type: schema.TypeString},
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateIPAddressOrCIDR,
+					},
 					Optional:    true,
 					Computed:    true,
-					Description: "A list of IPs.",
+					Description: "A list of IPs or CIDR ranges.",
 				},
 				"use_cert_auth": {
 					Type:        schema.TypeBool,
@@ -391,22 +419,26 @@ var resourceSchema = map[string]*schema.Schema{
 				"totp": {
 					Type:     schema.TypeList,
 					Optional: true,
+					Computed: true,
 					MaxItems: 1,
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"time_step": {
 								Type:        schema.TypeInt,
 								Optional:    true,
+								Computed:    true,
 								Description: "Seconds between allowed generation of new passwords.",
 							},
 							"length": {
 								Type:        schema.TypeInt,
 								Optional:    true,
+								Computed:    true,
 								Description: "Length of the one-time password.",
 							},
 						},
 					},
-					Description: "Configuration options for one-time passwords.",
+					Description: "Configuration options for one-time passwords. " +
+						"Any value not set is defaulted by Auth0.",
 				},
 				"messaging_service_sid": {
 					Type:        schema.TypeString,
@@ -445,9 +477,10 @@ var resourceSchema = map[string]*schema.Schema{
 					}, false),
 				},
 				"gateway_url": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Defines a custom sms gateway to use instead of Twilio.",
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Defines a custom sms gateway to use instead of Twilio. " +
+						"Required when `provider` is set to `sms_gateway`.",
 				},
 				"gateway_authentication": {
 					Type:        schema.TypeList,
@@ -457,9 +490,11 @@ var resourceSchema = map[string]*schema.Schema{
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"method": {
-								Type:        schema.TypeString,
-								Optional:    true,
-								Description: "Authentication method (default is `bearer` token).",
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice(smsGatewayAuthenticationMethods, false),
+								Description: "Authentication method (default is `bearer` token). " +
+									"With `bearer`, the `subject`, `audience` and `secret` must be set.",
 							},
 							"subject": {
 								Type:        schema.TypeString,
@@ -486,9 +521,10 @@ var resourceSchema = map[string]*schema.Schema{
 					},
 				},
 				"forward_request_info": {
-					Type:        schema.TypeBool,
-					Optional:    true,
-					Description: "Specifies whether or not request info should be forwarded to sms gateway.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Description: "Specifies whether or not request info should be forwarded to sms gateway. " +
+						"Requires `provider` to be set to `sms_gateway` and a `gateway_url`.",
 				},
 
 				"set_user_root_attributes": {
@@ -496,7 +532,7 @@ var resourceSchema = map[string]*schema.Schema{
 					Optional: true,
 					Computed: true,
 					ValidateFunc: validation.StringInSlice([]string{
-						"on_each_login", "on_first_login",
+						"on_each_login", "on_first_login", "on_both_login",
 					}, false),
 					Description: "Determines whether the 'name', 'given_name', 'family_name', 'nickname', " +
 						"and 'picture' attributes can be independently updated when using an external IdP. " +
@@ -504,15 +540,19 @@ var resourceSchema = map[string]*schema.Schema{
 						"automatically update the root attributes from the external IdP with each user login. " +
 						"When this setting is used, root attributes cannot be independently updated), " +
 						"'on_first_login' (configures the connection to only set the root attributes on " +
-						"first login, allowing them to be independently updated thereafter).",
+						"first login, allowing them to be independently updated thereafter) and " +
+						"'on_both_login', for the strategies that support it. It has no effect on database " +
+						"connections, unless `import_mode` is enabled.",
 				},
 				"non_persistent_attrs": {
-					Type:     schema.TypeSet,
-					Elem:     &schema.Schema{Type: schema.TypeString},
-					Optional: true,
-					Computed: true,
+					Type:             schema.TypeSet,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					Optional:         true,
+					Computed:         true,
+					DiffSuppressFunc: suppressEquivalentNonPersistentAttrsDiff,
 					Description: "If there are user fields that should not be stored in Auth0 databases due to " +
-						"privacy reasons, you can add them to the DenyList here.",
+						"privacy reasons, you can add them to the DenyList here. Attributes are compared " +
+						"regardless of their order, duplicates and surrounding whitespace.",
 				},
 				"should_trust_email_verified_connection": {
 					Type:     schema.TypeString,
@@ -525,17 +565,18 @@ var resourceSchema = map[string]*schema.Schema{
 				"team_id": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Description: "Apple Team ID.",
+					Description: "Apple Team ID. Only supported by connections with the `apple` strategy.",
 				},
 				"key_id": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Description: "Apple Key ID.",
+					Description: "Apple Key ID. Only supported by connections with the `apple` strategy.",
 				},
 				"adfs_server": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "ADFS URL where to fetch the metadata source.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Description:  "ADFS URL where to fetch the metadata source.",
 				},
 				"fed_metadata_xml": {
 					Type:        schema.TypeString,
@@ -543,9 +584,10 @@ var resourceSchema = map[string]*schema.Schema{
 					Description: "Federation Metadata for the ADFS connection.",
 				},
 				"community_base_url": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Salesforce community base URL.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Description:  "Salesforce community base URL.",
 				},
 				"strategy_version": {
 					Type:        schema.TypeInt,
@@ -560,7 +602,19 @@ var resourceSchema = map[string]*schema.Schema{
 					Elem:     &schema.Schema{Type: schema.TypeString},
 					Description: "Permissions to grant to the connection. Within the Auth0 dashboard these appear " +
 						"under the \"Attributes\" and \"Extended Attributes\" sections. Some examples: " +
-						"`basic_profile`, `ext_profile`, `ext_nested_groups`, etc.",
+						"`basic_profile`, `ext_profile`, `ext_nested_groups`, etc. Connections with the " +
+						"`apple` strategy only support the `name` and `email` scopes.",
+				},
+				"scope": {
+					Type:          schema.TypeList,
+					Computed:      true,
+					Optional:      true,
+					Elem:          &schema.Schema{Type: schema.TypeString},
+					ConflictsWith: []string{"options.0.scopes"},
+					Description: "Ordered list of scopes to request from the identity provider. Unlike `scopes`, " +
+						"the order in which they are specified is preserved, as some identity providers " +
+						"depend on it. Only supported by connections with the `oauth2` or `oidc` strategy. " +
+						"Conflicts with `scopes`.",
 				},
 				"type": {
 					Type:        schema.TypeString,
@@ -568,39 +622,45 @@ var resourceSchema = map[string]*schema.Schema{
 					Description: "Value can be `back_channel` or `front_channel`.",
 				},
 				"issuer": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "Issuer URL, e.g. `https://auth.example.com`.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Computed:     true,
+					Description:  "Issuer URL, e.g. `https://auth.example.com`.",
 				},
 				"jwks_uri": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "JWKS URI.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Computed:     true,
+					Description:  "JWKS URI.",
 				},
 				"discovery_url": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "OpenID discovery URL, e.g. `https://auth.example.com/.well-known/openid-configuration`.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Description:  "OpenID discovery URL, e.g. `https://auth.example.com/.well-known/openid-configuration`.",
 				},
 				"token_endpoint": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "Token endpoint.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Computed:     true,
+					Description:  "Token endpoint.",
 				},
 				"userinfo_endpoint": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "User info endpoint.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Computed:     true,
+					Description:  "User info endpoint.",
 				},
 				"authorization_endpoint": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "Authorization endpoint.",
+					Type:         schema.TypeString,
+					ValidateFunc: internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
+					Optional:     true,
+					Computed:     true,
+					Description:  "Authorization endpoint.",
 				},
 				"debug": {
 					Type:        schema.TypeBool,
@@ -608,26 +668,32 @@ var resourceSchema = map[string]*schema.Schema{
 					Description: "When enabled, additional debug information will be generated.",
 				},
 				"signing_cert": {
-					Type:     schema.TypeString,
-					Optional: true,
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressMetadataURLDerivedDiff,
 					Description: "X.509 signing certificate (encoded in PEM or CER) you retrieved " +
-						"from the IdP, Base64-encoded.",
+						"from the IdP, Base64-encoded. Derived from the metadata document " +
+						"when `metadata_url` is set and it's not configured.",
 				},
 				"signing_key": {
 					Type:     schema.TypeList,
 					Optional: true,
 					MaxItems: 1,
 					Description: "The key used to sign requests in the connection. Uses the `key` and `cert` " +
-						"properties to provide the private key and certificate respectively.",
+						"properties to provide the private key and certificate respectively. Changing either " +
+						"of them rotates the signing key of the connection.",
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"key": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:        schema.TypeString,
+								Required:    true,
+								Sensitive:   true,
+								Description: "The private key used to sign requests.",
 							},
 							"cert": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The certificate matching the private key.",
 							},
 						},
 					},
@@ -662,6 +728,8 @@ var resourceSchema = map[string]*schema.Schema{
 							"client_id": {
 								Type:     schema.TypeString,
 								Optional: true,
+								Description: "The ID of the client to log in to. Set `validate_idp_initiated_client_id` " +
+									"to check that it exists.",
 							},
 							"client_protocol": {
 								Type:     schema.TypeString,
@@ -675,9 +743,11 @@ var resourceSchema = map[string]*schema.Schema{
 					},
 				},
 				"sign_in_endpoint": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "SAML single login URL for the connection.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressMetadataURLDerivedDiff,
+					Description: "SAML single login URL for the connection. Derived from the metadata " +
+						"document when `metadata_url` is set and it's not configured.",
 				},
 				"sign_out_endpoint": {
 					Type:        schema.TypeString,
@@ -697,16 +767,19 @@ var resourceSchema = map[string]*schema.Schema{
 				},
 				"metadata_url": {
 					Type:          schema.TypeString,
+					ValidateFunc:  internalValidation.IsURLWithHTTPorHTTPSorEmptyString,
 					Optional:      true,
 					Description:   "The URL of the SAML metadata document.",
 					ConflictsWith: []string{"options.0.metadata_xml"},
 				},
 				"fields_map": {
-					Type:         schema.TypeString,
-					Optional:     true,
-					ValidateFunc: validation.StringIsJSON,
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateConnectionFieldsMap(),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 					Description: "If you're configuring a SAML enterprise connection for a non-standard " +
-						"PingFederate Server, you must update the attribute mappings.",
+						"PingFederate Server, you must update the attribute mappings. " +
+						"Each attribute must map to a string or an array of strings.",
 				},
 				"sign_saml_request": {
 					Type:        schema.TypeBool,
@@ -714,19 +787,29 @@ var resourceSchema = map[string]*schema.Schema{
 					Description: "When enabled, the SAML authentication request will be signed.",
 				},
 				"signature_algorithm": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Sign Request Algorithm.",
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"rsa-sha256", "rsa-sha1",
+					}, false),
+					Description: "Sign Request Algorithm. Options include `rsa-sha256` and `rsa-sha1`. " +
+						"Required when `sign_saml_request` is enabled.",
 				},
 				"digest_algorithm": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Sign Request Algorithm Digest.",
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"sha256", "sha1",
+					}, false),
+					Description: "Sign Request Algorithm Digest. Options include `sha256` and `sha1`. " +
+						"Required when `sign_saml_request` is enabled.",
 				},
 				"entity_id": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Custom Entity ID for the connection.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressMetadataURLDerivedDiff,
+					Description: "Custom Entity ID for the connection. Derived from the metadata " +
+						"document when `metadata_url` is set and it's not configured.",
 				},
 				"pkce_enabled": {
 					Type:        schema.TypeBool,
@@ -734,20 +817,23 @@ var resourceSchema = map[string]*schema.Schema{
 					Description: "Enables Proof Key for Code Exchange (PKCE) functionality for OAuth2 connections.",
 				},
 				"upstream_params": {
-					Type:         schema.TypeString,
-					Optional:     true,
-					ValidateFunc: validation.StringIsJSON,
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateConnectionUpstreamParams(),
 					Description: "You can pass provider-specific parameters to an identity provider during " +
-						"authentication. The values can either be static per connection or dynamic per user.",
+						"authentication. The values can either be static per connection or dynamic per user. " +
+						"Each parameter must map to an object with either an `alias` or a `value`.",
 				},
 				"auth_params": {
 					Type: schema.TypeMap,
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
-					Optional: true,
+					Optional:         true,
+					ValidateDiagFunc: validateConnectionEmailAuthParams(),
 					Description: "Query string parameters to be included as part " +
-						"of the generated passwordless email link.",
+						"of the generated passwordless email link. Only the `scope` and " +
+						"`response_type` keys are supported.",
 				},
 			},
 		},
@@ -758,20 +844,67 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional: true,
 		Computed: true,
 		Description: "Defines the realms for which the connection will be used (e.g., email domains). " +
-			"If not specified, the connection name is added as the realm.",
+			"If not specified, the connection name is added as the realm. Each realm must be unique. " +
+			"Set `validate_realms_uniqueness` to check that they are not used by another connection.",
 	},
 	"show_as_button": {
-		Type:        schema.TypeBool,
-		Optional:    true,
-		Description: "Display connection as a button. Only available on enterprise connections.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Display connection as a button. Only available on enterprise connections: " +
+			"`" + strings.Join(enterpriseStrategies, "`, `") + "`.",
 	},
 	"enabled_clients": {
 		Type: schema.TypeSet,
 		Elem: &schema.Schema{
 			Type: schema.TypeString,
 		},
-		Computed:    true,
-		Description: "IDs of the clients for which the connection is enabled.",
+		Optional: true,
+		Computed: true,
+		Description: "IDs of the clients for which the connection is enabled. If set, this resource " +
+			"authoritatively manages the enabled clients and will disable any client enabled outside of it. " +
+			"Do not use it together with the `auth0_connection_client` or `auth0_connection_clients` " +
+			"resources on the same connection.",
+	},
+	"signing_keys": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cert": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The signing certificate, in PEM format.",
+				},
+				"subject": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The subject of the signing certificate, as a JSON string.",
+				},
+			},
+		},
+		Description: "The signing certificates of the connection. Only available on `samlp` connections. " +
+			"The private key configured through `signing_key` is not exposed here, as Terraform " +
+			"cannot mask nested attributes of computed lists in the plan output.",
+	},
+	"validate_idp_initiated_client_id": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Set this to `true` to check that the client referenced by " +
+			"`options.0.idp_initiated.0.client_id` exists before creating or updating the connection, " +
+			"at the cost of an extra API call.",
+	},
+	"validate_realms_uniqueness": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Set this to `true` to check that the `realms` are not already used by another connection " +
+			"before creating or updating the connection, at the cost of listing all the connections of the tenant.",
+	},
+	"validate_display_name_uniqueness": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Set this to `true` to warn when the `display_name` is already used by another connection " +
+			"before creating or updating the connection, at the cost of listing all the connections of the tenant. " +
+			"Duplicated display names are still allowed.",
 	},
 }
 
@@ -866,3 +999,82 @@ func connectionSchemaUpgradeV1(
 
 	return state, nil
 }
+
+// suppressEquivalentIntegerDiff suppresses the diff of a string attribute
+// holding a number when the old and new values represent the same integer.
+func suppressEquivalentIntegerDiff(_, old, new string, _ *schema.ResourceData) bool {
+	oldValue, err := strconv.Atoi(strings.TrimSpace(old))
+	if err != nil {
+		return false
+	}
+
+	newValue, err := strconv.Atoi(strings.TrimSpace(new))
+	if err != nil {
+		return false
+	}
+
+	return oldValue == newValue
+}
+
+// suppressEquivalentNonPersistentAttrsDiff suppresses the diff of the
+// non_persistent_attrs set when the old and new attributes are the same once
+// normalized the way they are when read back from the API.
+func suppressEquivalentNonPersistentAttrsDiff(k, _, _ string, d *schema.ResourceData) bool {
+	attribute := k[:strings.LastIndex(k, "non_persistent_attrs")+len("non_persistent_attrs")]
+
+	oldValue, newValue := d.GetChange(attribute)
+	oldSet, ok := oldValue.(*schema.Set)
+	if !ok {
+		return false
+	}
+	newSet, ok := newValue.(*schema.Set)
+	if !ok {
+		return false
+	}
+
+	return equalNonPersistentAttrs(oldSet.List(), newSet.List())
+}
+
+func equalNonPersistentAttrs(old, new []interface{}) bool {
+	oldAttrs := make([]string, 0, len(old))
+	for _, attr := range old {
+		oldAttrs = append(oldAttrs, attr.(string))
+	}
+
+	newAttrs := make([]string, 0, len(new))
+	for _, attr := range new {
+		newAttrs = append(newAttrs, attr.(string))
+	}
+
+	return reflect.DeepEqual(
+		flattenConnectionOptionsNonPersistentAttrs(oldAttrs),
+		flattenConnectionOptionsNonPersistentAttrs(newAttrs),
+	)
+}
+
+// suppressMetadataURLDerivedDiff ignores the SAML options that the API derives
+// from the metadata document when `metadata_url` is set, as long as they
+// are not configured, so that they behave as computed attributes.
+func suppressMetadataURLDerivedDiff(_, _, newValue string, d *schema.ResourceData) bool {
+	return newValue == "" && d.Get("options.0.metadata_url").(string) != ""
+}
+
+// suppressScriptFormattingDiff ignores differences in trailing whitespace
+// and newlines in custom scripts, which Auth0 sometimes reformats when
+// storing them, while still reporting any change to the script content.
+func suppressScriptFormattingDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	if oldValue == "" || newValue == "" {
+		return false
+	}
+
+	return normalizeScript(oldValue) == normalizeScript(newValue)
+}
+
+func normalizeScript(script string) string {
+	lines := strings.Split(script, "\n")
+	for index, line := range lines {
+		lines[index] = strings.TrimRight(line, " \t\r")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -3,7 +3,16 @@ package connection
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConnectionInstanceStateUpgradeV0(t *testing.T) {
@@ -58,6 +67,58 @@ func TestConnectionInstanceStateUpgradeV0(t *testing.T) {
 	}
 }
 
+func TestConnectionStrategyVersionIsStableAfterStateUpgradeV0(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		version interface{}
+		options interface{}
+	}{
+		{
+			name:    "GitHubWithoutVersion",
+			version: "",
+			options: &management.ConnectionOptionsGitHub{},
+		},
+		{
+			name:    "FacebookWithLeftoverVersion",
+			version: "0",
+			options: &management.ConnectionOptionsFacebook{},
+		},
+		{
+			name:    "WindowsLiveWithVersion",
+			version: "2",
+			options: &management.ConnectionOptionsWindowsLive{StrategyVersion: auth0.Int(2)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := map[string]interface{}{
+				"options": []interface{}{
+					map[string]interface{}{"strategy_version": tt.version},
+				},
+			}
+
+			upgradedState, err := connectionSchemaUpgradeV0(context.Background(), state, nil)
+			if err != nil {
+				t.Fatalf("error migrating state: %s", err)
+			}
+			upgradedOptions := upgradedState["options"].([]interface{})[0].(map[string]interface{})
+
+			flattenedOptions, diags := flattenConnectionOptions(nil, tt.options)
+			if diags.HasError() {
+				t.Fatalf("error flattening options: %v", diags)
+			}
+			readOptions := flattenedOptions[0].(map[string]interface{})
+
+			if upgradedOptions["strategy_version"] != readOptions["strategy_version"] {
+				t.Fatalf(
+					"expected the strategy version read from the API (%#v) to match the upgraded state (%#v)",
+					readOptions["strategy_version"],
+					upgradedOptions["strategy_version"],
+				)
+			}
+		})
+	}
+}
+
 func TestConnectionInstanceStateUpgradeV1(t *testing.T) {
 	for _, tt := range []struct {
 		name               string
@@ -121,3 +182,420 @@ func TestConnectionInstanceStateUpgradeV1(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectionInstanceStateUpgradeV1WithoutValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		state func() map[string]interface{}
+	}{
+		{
+			name: "WithoutOptions",
+			state: func() map[string]interface{} {
+				return map[string]interface{}{"name": "my-connection"}
+			},
+		},
+		{
+			name: "WithoutValidation",
+			state: func() map[string]interface{} {
+				return map[string]interface{}{
+					"options": []interface{}{
+						map[string]interface{}{"password_policy": "good"},
+					},
+				}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := connectionSchemaUpgradeV1(context.Background(), tt.state(), nil)
+			if err != nil {
+				t.Fatalf("error migrating state: %s", err)
+			}
+
+			expected := tt.state()
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentIntegerDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "Equal",
+			old:      "100",
+			new:      "100",
+			expected: true,
+		},
+		{
+			name:     "LeadingZeros",
+			old:      "100",
+			new:      "0100",
+			expected: true,
+		},
+		{
+			name:     "Whitespace",
+			old:      "100",
+			new:      " 100 ",
+			expected: true,
+		},
+		{
+			name:     "Different",
+			old:      "100",
+			new:      "250",
+			expected: false,
+		},
+		{
+			name:     "Unset",
+			old:      "",
+			new:      "100",
+			expected: false,
+		},
+		{
+			name:     "NotANumber",
+			old:      "foo",
+			new:      "foo",
+			expected: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := suppressEquivalentIntegerDiff("options.0.max_groups_to_retrieve", tt.old, tt.new, nil)
+			if actual != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuppressFieldsMapJSONDiff(t *testing.T) {
+	suppressDiff := resourceSchema["options"].Elem.(*schema.Resource).Schema["fields_map"].DiffSuppressFunc
+	require.NotNil(t, suppressDiff)
+
+	// The API can return the fields map with its keys reordered or reformatted.
+	assert.True(t, suppressDiff(
+		"options.0.fields_map",
+		`{"name":["name","nameId"],"email":"email"}`,
+		"{\n  \"email\": \"email\",\n  \"name\": [\"name\", \"nameId\"]\n}",
+		nil,
+	))
+	assert.False(t, suppressDiff(
+		"options.0.fields_map",
+		`{"email":"email"}`,
+		`{"email":"upn"}`,
+		nil,
+	))
+}
+
+func TestEqualNonPersistentAttrs(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		old      []interface{}
+		new      []interface{}
+		expected bool
+	}{
+		{
+			name:     "ReversedOrder",
+			old:      []interface{}{"gender", "ethnicity"},
+			new:      []interface{}{"ethnicity", "gender"},
+			expected: true,
+		},
+		{
+			name:     "SurroundingWhitespace",
+			old:      []interface{}{"gender", "ethnicity"},
+			new:      []interface{}{"ethnicity ", "gender"},
+			expected: true,
+		},
+		{
+			name:     "RemovedAttribute",
+			old:      []interface{}{"gender", "ethnicity"},
+			new:      []interface{}{"gender"},
+			expected: false,
+		},
+		{
+			name:     "AddedAttribute",
+			old:      []interface{}{"gender"},
+			new:      []interface{}{"gender", "address"},
+			expected: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := equalNonPersistentAttrs(tt.old, tt.new); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuppressMetadataURLDerivedDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		metadataURL string
+		newValue    string
+		expected    bool
+	}{
+		{
+			name:        "DerivedFromMetadataURL",
+			metadataURL: "https://saml.provider/metadata.xml",
+			newValue:    "",
+			expected:    true,
+		},
+		{
+			name:        "ConfiguredWithMetadataURL",
+			metadataURL: "https://saml.provider/metadata.xml",
+			newValue:    "https://saml.provider/sign_in",
+			expected:    false,
+		},
+		{
+			name:        "RemovedWithoutMetadataURL",
+			metadataURL: "",
+			newValue:    "",
+			expected:    false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, map[string]interface{}{
+				"strategy": "samlp",
+				"options": []interface{}{
+					map[string]interface{}{"metadata_url": tt.metadataURL},
+				},
+			})
+
+			actual := suppressMetadataURLDerivedDiff(
+				"options.0.sign_in_endpoint",
+				"https://saml.provider/sign_in",
+				tt.newValue,
+				data,
+			)
+			if actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuppressScriptFormattingDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "Equal",
+			old:      "function login(email, password, callback) {}",
+			new:      "function login(email, password, callback) {}",
+			expected: true,
+		},
+		{
+			name:     "TrailingNewline",
+			old:      "function login(email, password, callback) {}\n",
+			new:      "function login(email, password, callback) {}",
+			expected: true,
+		},
+		{
+			name:     "TrailingWhitespaceOnLines",
+			old:      "function login(email, password, callback) {  \r\n  callback();\t\n}",
+			new:      "function login(email, password, callback) {\n  callback();\n}\n\n",
+			expected: true,
+		},
+		{
+			name:     "DifferentIndentation",
+			old:      "function login(email, password, callback) {\n  callback();\n}",
+			new:      "function login(email, password, callback) {\ncallback();\n}",
+			expected: false,
+		},
+		{
+			name:     "DifferentContent",
+			old:      "function login(email, password, callback) {}\n",
+			new:      "function getUser(email, callback) {}",
+			expected: false,
+		},
+		{
+			name:     "Removed",
+			old:      "\n",
+			new:      "",
+			expected: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := suppressScriptFormattingDiff("options.0.custom_scripts.login", tt.old, tt.new, nil)
+			if actual != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSensitiveAttributesAreMaskedInPlan(t *testing.T) {
+	// Terraform masks the plan output based on the
+	// core schema, so we assert against it directly.
+	resourceSchema := NewResource().CoreConfigSchema()
+	options := resourceSchema.BlockTypes["options"].Block
+
+	for _, attribute := range []string{"client_secret", "twilio_token", "configuration"} {
+		require.Containsf(t, options.Attributes, attribute, "Expected options.%s to exist", attribute)
+		assert.Truef(t, options.Attributes[attribute].Sensitive, "Expected options.%s to be sensitive", attribute)
+	}
+
+	gatewaySecret := options.BlockTypes["gateway_authentication"].Block.Attributes["secret"]
+	assert.True(t, gatewaySecret.Sensitive, "Expected options.gateway_authentication.secret to be sensitive")
+
+	signingKey := options.BlockTypes["signing_key"].Block.Attributes["key"]
+	assert.True(t, signingKey.Sensitive, "Expected options.signing_key.key to be sensitive")
+
+	// Nested attributes of computed only lists can't be masked,
+	// so the signing keys must not expose any private key.
+	signingKeys := resourceSchema.Attributes["signing_keys"]
+	require.NotNil(t, signingKeys)
+	assert.NotContains(t, signingKeys.Type.ElementType().AttributeTypes(), "key")
+
+	// The data source blanks the option secrets instead, so
+	// that the other options can still be used in outputs.
+	dataSourceSchema := NewDataSource().CoreConfigSchema()
+	require.Contains(t, dataSourceSchema.Attributes, "options")
+	assert.False(t, dataSourceSchema.Attributes["options"].Sensitive, "Expected the data source options not to be sensitive")
+}
+
+func TestBruteForceProtectionDefaultDoesNotDrift(t *testing.T) {
+	resource := NewResource()
+
+	// The API enables brute force protection by default.
+	state := &terraform.InstanceState{
+		ID: "con_123",
+		Attributes: map[string]string{
+			"id":                               "con_123",
+			"name":                             "Acme",
+			"strategy":                         "auth0",
+			"options.#":                        "1",
+			"options.0.password_policy":        "fair",
+			"options.0.brute_force_protection": "true",
+		},
+		RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+			"name":     cty.StringVal("Acme"),
+			"strategy": cty.StringVal("auth0"),
+			"options": newRawOptions(t, map[string]cty.Value{
+				"password_policy": cty.StringVal("fair"),
+			}),
+		}),
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "Acme",
+		"strategy": "auth0",
+		"options": []interface{}{
+			map[string]interface{}{"password_policy": "fair"},
+		},
+	})
+
+	diff, err := resource.Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+
+	if diff != nil {
+		assert.NotContains(t, diff.Attributes, "options.0.brute_force_protection")
+	}
+}
+
+func TestDefaultRealmDoesNotDrift(t *testing.T) {
+	resource := NewResource()
+
+	// The API adds the connection name as the only realm by default.
+	state := &terraform.InstanceState{
+		ID: "con_123",
+		Attributes: map[string]string{
+			"id":       "con_123",
+			"name":     "Acme",
+			"strategy": "auth0",
+			"realms.#": "1",
+			"realms.0": "Acme",
+		},
+		RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+			"name":     cty.StringVal("Acme"),
+			"strategy": cty.StringVal("auth0"),
+		}),
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "Acme",
+		"strategy": "auth0",
+	})
+
+	diff, err := resource.Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+
+	if diff != nil {
+		for key := range diff.Attributes {
+			assert.False(t, strings.HasPrefix(key, "realms"), "Expected realms not to change, got %s", key)
+		}
+	}
+}
+
+func TestExplicitRealmsAfterDefaultRealm(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		givenRealms    []string
+		expectedChange bool
+	}{
+		{
+			name:           "setting the default realm explicitly doesn't change anything",
+			givenRealms:    []string{"Acme"},
+			expectedChange: false,
+		},
+		{
+			name:           "setting other realms explicitly is a real change",
+			givenRealms:    []string{"acme.com"},
+			expectedChange: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resource := NewResource()
+
+			// The API added the connection name as the only realm.
+			state := &terraform.InstanceState{
+				ID: "con_123",
+				Attributes: map[string]string{
+					"id":       "con_123",
+					"name":     "Acme",
+					"strategy": "auth0",
+					"realms.#": "1",
+					"realms.0": "Acme",
+				},
+				RawConfig: newRawConfig(t, resource, map[string]cty.Value{
+					"name":     cty.StringVal("Acme"),
+					"strategy": cty.StringVal("auth0"),
+				}),
+			}
+
+			realms := make([]interface{}, 0, len(testCase.givenRealms))
+			for _, realm := range testCase.givenRealms {
+				realms = append(realms, realm)
+			}
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":     "Acme",
+				"strategy": "auth0",
+				"realms":   realms,
+			})
+
+			diff, err := resource.Diff(context.Background(), state, config, nil)
+			require.NoError(t, err)
+
+			realmsChanged := false
+			if diff != nil {
+				for key := range diff.Attributes {
+					if strings.HasPrefix(key, "realms") {
+						realmsChanged = true
+					}
+				}
+			}
+
+			assert.Equal(t, testCase.expectedChange, realmsChanged)
+		})
+	}
+}
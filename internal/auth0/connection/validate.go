@@ -0,0 +1,1195 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
+)
+
+var (
+	errSAMLSignInEndpointWithoutCert = fmt.Errorf(
+		"options.0.sign_in_endpoint: one of `options.0.signing_cert`, `options.0.metadata_url` " +
+			"or `options.0.metadata_xml` must be specified when `sign_in_endpoint` is set on a SAML connection",
+	)
+	errSAMLProtocolBindingWithoutSignInEndpoint = fmt.Errorf(
+		"options.0.protocol_binding: `options.0.sign_in_endpoint` must be specified " +
+			"when `protocol_binding` is set on a SAML connection",
+	)
+	errSAMLSignRequestWithoutAlgorithms = fmt.Errorf(
+		"options.0.sign_saml_request: `options.0.signature_algorithm` and `options.0.digest_algorithm` " +
+			"must be specified when `sign_saml_request` is enabled on a SAML connection",
+	)
+	errSMSGatewayWithoutURL = fmt.Errorf(
+		"options.0.gateway_url: `options.0.gateway_url` must be specified " +
+			"when `provider` is set to \"sms_gateway\" on an SMS connection",
+	)
+	errForwardRequestInfoWithoutSMSGateway = fmt.Errorf(
+		"options.0.forward_request_info: `forward_request_info` can only be enabled on SMS connections " +
+			"with `provider` set to \"sms_gateway\" and a `gateway_url`, as it would otherwise be ignored",
+	)
+	errDatabaseCustomizationWithoutLoginScript = fmt.Errorf(
+		"options.0.custom_scripts: a `login` script must be specified when " +
+			"`enabled_database_customization` is enabled, or users won't be able to log in. " +
+			"Either add the script or set `enabled_database_customization` to false",
+	)
+)
+
+// strategySpecificOptions holds the options that
+// are only supported by a subset of the strategies.
+var strategySpecificOptions = map[string][]string{
+	"twilio_sid":   {management.ConnectionStrategySMS},
+	"twilio_token": {management.ConnectionStrategySMS},
+	"from":         {management.ConnectionStrategySMS, management.ConnectionStrategyEmail},
+	"template":     {management.ConnectionStrategySMS, management.ConnectionStrategyEmail},
+	"totp":         {management.ConnectionStrategySMS, management.ConnectionStrategyEmail},
+
+	"signing_cert":        {management.ConnectionStrategySAML},
+	"signing_key":         {management.ConnectionStrategySAML},
+	"protocol_binding":    {management.ConnectionStrategySAML},
+	"request_template":    {management.ConnectionStrategySAML},
+	"user_id_attribute":   {management.ConnectionStrategySAML},
+	"idp_initiated":       {management.ConnectionStrategySAML},
+	"sign_out_endpoint":   {management.ConnectionStrategySAML},
+	"disable_sign_out":    {management.ConnectionStrategySAML},
+	"metadata_xml":        {management.ConnectionStrategySAML},
+	"metadata_url":        {management.ConnectionStrategySAML},
+	"fields_map":          {management.ConnectionStrategySAML},
+	"sign_saml_request":   {management.ConnectionStrategySAML},
+	"signature_algorithm": {management.ConnectionStrategySAML},
+	"digest_algorithm":    {management.ConnectionStrategySAML},
+	"entity_id":           {management.ConnectionStrategySAML},
+	"debug":               {management.ConnectionStrategySAML},
+
+	"scope": {management.ConnectionStrategyOAuth2, management.ConnectionStrategyOIDC},
+
+	"team_id": {management.ConnectionStrategyApple},
+	"key_id":  {management.ConnectionStrategyApple},
+}
+
+// enterpriseStrategies holds the strategies of enterprise connections,
+// the only ones that can be displayed as a button on the login page.
+var enterpriseStrategies = []string{
+	management.ConnectionStrategyAD,
+	management.ConnectionStrategyADFS,
+	"auth0-adldap",
+	management.ConnectionStrategyCustom,
+	management.ConnectionStrategyGoogleApps,
+	"ip",
+	"office365",
+	management.ConnectionStrategyOIDC,
+	management.ConnectionStrategyOkta,
+	"pingfederate",
+	management.ConnectionStrategySAML,
+	"sharepoint",
+	management.ConnectionStrategyAzureAD,
+}
+
+// appleConnectionScopes holds the only scopes supported by Apple,
+// any other scope would be silently dropped by the SDK.
+var appleConnectionScopes = []string{"email", "name"}
+
+// validateStrategySpecificOptions rejects at plan time the options that are
+// not supported by the strategy of the connection, as the API would
+// otherwise silently ignore them.
+func validateStrategySpecificOptions(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() {
+		return nil
+	}
+
+	return checkStrategySpecificOptions(strategy.AsString(), config.GetAttr("options"))
+}
+
+func checkStrategySpecificOptions(strategy string, rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	optionNames := make([]string, 0, len(strategySpecificOptions))
+	for optionName := range strategySpecificOptions {
+		optionNames = append(optionNames, optionName)
+	}
+	sort.Strings(optionNames)
+
+	var result *multierror.Error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		for _, optionName := range optionNames {
+			supportedStrategies := strategySpecificOptions[optionName]
+			if isStrategySupported(strategy, supportedStrategies) || !isOptionSet(options, optionName) {
+				continue
+			}
+
+			quotedStrategies := make([]string, 0, len(supportedStrategies))
+			for _, supportedStrategy := range supportedStrategies {
+				quotedStrategies = append(quotedStrategies, fmt.Sprintf("%q", supportedStrategy))
+			}
+
+			result = multierror.Append(result, fmt.Errorf(
+				"options.0.%s: this option is only supported by connections with the %s strategy, "+
+					"but the connection uses the %q strategy",
+				optionName,
+				strings.Join(quotedStrategies, " or "),
+				strategy,
+			))
+		}
+
+		return stop
+	})
+
+	return result.ErrorOrNil()
+}
+
+// validateShowAsButton rejects at plan time show_as_button on connections
+// that are not enterprise ones, as the API would otherwise silently ignore it.
+func validateShowAsButton(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() {
+		return nil
+	}
+
+	return checkShowAsButton(strategy.AsString(), config.GetAttr("show_as_button"))
+}
+
+func checkShowAsButton(strategy string, showAsButton cty.Value) error {
+	if showAsButton.IsNull() || isStrategySupported(strategy, enterpriseStrategies) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"show_as_button: this attribute is only supported by enterprise connections (%s), "+
+			"but the connection uses the %q strategy",
+		strings.Join(enterpriseStrategies, ", "),
+		strategy,
+	)
+}
+
+func isStrategySupported(strategy string, supportedStrategies []string) bool {
+	for _, supportedStrategy := range supportedStrategies {
+		if strategy == supportedStrategy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// strategyOptionsRequiringReplacement holds, per strategy, the options
+// that the API can't update in place on an existing connection.
+var strategyOptionsRequiringReplacement = map[string][]string{
+	management.ConnectionStrategyAzureAD: {"identity_api"},
+}
+
+// forceNewOnImmutableOptions plans the replacement of the connection when an
+// option that can't be updated in place changes, instead of letting the
+// update silently fail server side.
+func forceNewOnImmutableOptions(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	strategy := diff.Get("strategy").(string)
+	for _, key := range changedOptionsRequiringReplacement(strategy, diff.HasChange) {
+		if err := diff.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func changedOptionsRequiringReplacement(strategy string, hasChange func(key string) bool) []string {
+	var keys []string
+	for _, optionName := range strategyOptionsRequiringReplacement[strategy] {
+		key := "options.0." + optionName
+		if hasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// validateSAMLConnectionOptions checks at plan time the combinations of
+// options that the API requires to be set together on SAML connections.
+func validateSAMLConnectionOptions(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || strategy.AsString() != management.ConnectionStrategySAML {
+		return nil
+	}
+
+	return checkSAMLConnectionOptions(config.GetAttr("options"))
+}
+
+func checkSAMLConnectionOptions(rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var result *multierror.Error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		signInEndpointIsSet := isOptionSet(options, "sign_in_endpoint")
+
+		if signInEndpointIsSet &&
+			!isOptionSet(options, "signing_cert") &&
+			!isOptionSet(options, "metadata_url") &&
+			!isOptionSet(options, "metadata_xml") {
+			result = multierror.Append(result, errSAMLSignInEndpointWithoutCert)
+		}
+
+		if isOptionSet(options, "protocol_binding") && !signInEndpointIsSet {
+			result = multierror.Append(result, errSAMLProtocolBindingWithoutSignInEndpoint)
+		}
+
+		signSAMLRequest := options.GetAttr("sign_saml_request")
+		if signSAMLRequest.IsKnown() && !signSAMLRequest.IsNull() && signSAMLRequest.True() &&
+			(!isOptionSet(options, "signature_algorithm") || !isOptionSet(options, "digest_algorithm")) {
+			result = multierror.Append(result, errSAMLSignRequestWithoutAlgorithms)
+		}
+
+		return stop
+	})
+
+	return result.ErrorOrNil()
+}
+
+// validateAppleConnectionScopes rejects at plan time the scopes
+// that aren't supported by connections with the apple strategy.
+func validateAppleConnectionScopes(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || strategy.AsString() != management.ConnectionStrategyApple {
+		return nil
+	}
+
+	return checkAppleConnectionScopes(config.GetAttr("options"))
+}
+
+func checkAppleConnectionScopes(rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var result *multierror.Error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		scopes := options.GetAttr("scopes")
+		if !scopes.IsKnown() || scopes.IsNull() {
+			return stop
+		}
+
+		scopes.ForEachElement(func(_ cty.Value, scope cty.Value) (stop bool) {
+			if !scope.IsKnown() || scope.IsNull() || isAppleConnectionScope(scope.AsString()) {
+				return stop
+			}
+
+			result = multierror.Append(result, fmt.Errorf(
+				"options.0.scopes: %q is not supported by connections with the %q strategy, "+
+					"expected one of %q",
+				scope.AsString(),
+				management.ConnectionStrategyApple,
+				appleConnectionScopes,
+			))
+
+			return stop
+		})
+
+		return stop
+	})
+
+	return result.ErrorOrNil()
+}
+
+func isAppleConnectionScope(scope string) bool {
+	for _, appleConnectionScope := range appleConnectionScopes {
+		if scope == appleConnectionScope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oktaConnectionEndpoints holds the endpoints an okta connection needs
+// when they are not discovered through `discovery_url` or `domain`.
+var oktaConnectionEndpoints = []string{
+	"authorization_endpoint",
+	"issuer",
+	"jwks_uri",
+	"token_endpoint",
+	"userinfo_endpoint",
+}
+
+// validateOktaConnectionEndpoints rejects at plan time the okta connections
+// whose endpoints are neither discovered nor all configured manually.
+func validateOktaConnectionEndpoints(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || strategy.AsString() != management.ConnectionStrategyOkta {
+		return nil
+	}
+
+	return checkOktaConnectionEndpoints(config.GetAttr("options"))
+}
+
+func checkOktaConnectionEndpoints(rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var err error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		if isOptionSet(options, "discovery_url") {
+			return stop
+		}
+
+		var configuredEndpoints, missingEndpoints []string
+		for _, endpoint := range oktaConnectionEndpoints {
+			if isOptionSet(options, endpoint) {
+				configuredEndpoints = append(configuredEndpoints, endpoint)
+				continue
+			}
+			missingEndpoints = append(missingEndpoints, endpoint)
+		}
+
+		// Without any endpoint configured, they are discovered from the domain.
+		if len(missingEndpoints) == 0 || (len(configuredEndpoints) == 0 && isOptionSet(options, "domain")) {
+			return stop
+		}
+
+		err = fmt.Errorf(
+			"options.0: connections with the %q strategy need either `discovery_url` or all of "+
+				"the endpoints to be set, missing: %s",
+			management.ConnectionStrategyOkta,
+			strings.Join(missingEndpoints, ", "),
+		)
+
+		return stop
+	})
+
+	return err
+}
+
+// validateDatabaseCustomization rejects at plan time the database connections
+// using a custom database without a login script, as users would otherwise
+// only find out when they fail to log in.
+func validateDatabaseCustomization(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || strategy.AsString() != management.ConnectionStrategyAuth0 {
+		return nil
+	}
+
+	return checkDatabaseCustomization(config.GetAttr("options"))
+}
+
+func checkDatabaseCustomization(rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var err error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		enabled := options.GetAttr("enabled_database_customization")
+		if !enabled.IsKnown() || enabled.IsNull() || enabled.False() {
+			return stop
+		}
+
+		customScripts := options.GetAttr("custom_scripts")
+		if !customScripts.IsKnown() {
+			return stop
+		}
+
+		if !customScripts.IsNull() {
+			login, ok := customScripts.AsValueMap()["login"]
+			if ok && (!login.IsKnown() || (!login.IsNull() && login.AsString() != "")) {
+				return stop
+			}
+		}
+
+		err = errDatabaseCustomizationWithoutLoginScript
+
+		return stop
+	})
+
+	return err
+}
+
+// devKeysStrategies holds the social strategies that fall back to the Auth0
+// developer keys when no custom client credentials are configured.
+var devKeysStrategies = []string{
+	management.ConnectionStrategyFacebook,
+	management.ConnectionStrategyGitHub,
+	management.ConnectionStrategyGoogleOAuth2,
+	management.ConnectionStrategyLinkedin,
+	management.ConnectionStrategyWindowsLive,
+}
+
+// validateSocialClientCredentials rejects at plan time the social connections
+// with only one of `client_id` or `client_secret` configured, as they need
+// both to use custom keys, or neither to use the Auth0 developer keys.
+func validateSocialClientCredentials(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || !isStrategySupported(strategy.AsString(), devKeysStrategies) {
+		return nil
+	}
+
+	return checkSocialClientCredentials(strategy.AsString(), config.GetAttr("options"))
+}
+
+func checkSocialClientCredentials(strategy string, rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var err error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		clientIDSet := isOptionSet(options, "client_id")
+		clientSecretSet := isOptionSet(options, "client_secret")
+		if clientIDSet == clientSecretSet {
+			return stop
+		}
+
+		configured, missing := "client_id", "client_secret"
+		if clientSecretSet {
+			configured, missing = missing, configured
+		}
+
+		err = fmt.Errorf(
+			"options.0.%s: `options.0.%s` must also be specified when `%s` is set on a connection "+
+				"with the %q strategy. Set both to use custom keys, or neither to use the Auth0 developer keys",
+			configured,
+			missing,
+			configured,
+			strategy,
+		)
+
+		return stop
+	})
+
+	return err
+}
+
+// smsGatewayAuthenticationMethods holds the methods supported
+// to authenticate the requests sent to a custom SMS gateway.
+var smsGatewayAuthenticationMethods = []string{"bearer"}
+
+// smsGatewayBearerClaims holds the options needed to generate
+// the bearer token sent to a custom SMS gateway.
+var smsGatewayBearerClaims = []string{"audience", "secret", "subject"}
+
+// validateSMSGateway rejects at plan time the SMS connections using a
+// custom gateway that is not fully configured, as sending the one-time
+// passwords would otherwise only fail when users try to log in.
+func validateSMSGateway(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() || strategy.AsString() != management.ConnectionStrategySMS {
+		return nil
+	}
+
+	return checkSMSGateway(config.GetAttr("options"))
+}
+
+func checkSMSGateway(rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var result *multierror.Error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		provider := options.GetAttr("provider")
+		if provider.IsKnown() && !provider.IsNull() && provider.AsString() == "sms_gateway" &&
+			!isOptionSet(options, "gateway_url") {
+			result = multierror.Append(result, errSMSGatewayWithoutURL)
+		}
+
+		gatewayAuthentication := options.GetAttr("gateway_authentication")
+		if !gatewayAuthentication.IsKnown() || gatewayAuthentication.IsNull() {
+			return stop
+		}
+
+		gatewayAuthentication.ForEachElement(func(_ cty.Value, authentication cty.Value) (stop bool) {
+			// The method defaults to bearer when it's not set.
+			method := authentication.GetAttr("method")
+			if !method.IsKnown() || (!method.IsNull() && method.AsString() != "bearer") {
+				return stop
+			}
+
+			var missingClaims []string
+			for _, claim := range smsGatewayBearerClaims {
+				if !isOptionSet(authentication, claim) {
+					missingClaims = append(missingClaims, claim)
+				}
+			}
+
+			if len(missingClaims) > 0 {
+				result = multierror.Append(result, fmt.Errorf(
+					"options.0.gateway_authentication.0: the %q authentication method needs "+
+						"all of the token claims to be set, missing: %s",
+					"bearer",
+					strings.Join(missingClaims, ", "),
+				))
+			}
+
+			return stop
+		})
+
+		return stop
+	})
+
+	return result.ErrorOrNil()
+}
+
+// validateForwardRequestInfo rejects at plan time the connections forwarding
+// the request info without a custom SMS gateway to forward it to.
+func validateForwardRequestInfo(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	strategy := config.GetAttr("strategy")
+	if !strategy.IsKnown() || strategy.IsNull() {
+		return nil
+	}
+
+	return checkForwardRequestInfo(strategy.AsString(), config.GetAttr("options"))
+}
+
+func checkForwardRequestInfo(strategy string, rawOptions cty.Value) error {
+	if !rawOptions.IsKnown() || rawOptions.IsNull() {
+		return nil
+	}
+
+	var err error
+
+	rawOptions.ForEachElement(func(_ cty.Value, options cty.Value) (stop bool) {
+		forwardRequestInfo := options.GetAttr("forward_request_info")
+		if !forwardRequestInfo.IsKnown() || forwardRequestInfo.IsNull() || forwardRequestInfo.False() {
+			return stop
+		}
+
+		provider := options.GetAttr("provider")
+		if !provider.IsKnown() {
+			return stop
+		}
+
+		usesSMSGateway := strategy == management.ConnectionStrategySMS &&
+			!provider.IsNull() && provider.AsString() == "sms_gateway" &&
+			isOptionSet(options, "gateway_url")
+		if !usesSMSGateway {
+			err = errForwardRequestInfoWithoutSMSGateway
+		}
+
+		return stop
+	})
+
+	return err
+}
+
+// validateRealms rejects at plan time the realms listed more than once,
+// as they would make it ambiguous which connection users log in with.
+func validateRealms(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	return checkRealms(diff.GetRawConfig().GetAttr("realms"))
+}
+
+func checkRealms(rawRealms cty.Value) error {
+	if !rawRealms.IsKnown() || rawRealms.IsNull() {
+		return nil
+	}
+
+	seenRealms := make(map[string]bool)
+	for _, realm := range rawRealms.AsValueSlice() {
+		if !realm.IsKnown() || realm.IsNull() {
+			continue
+		}
+
+		if seenRealms[realm.AsString()] {
+			return fmt.Errorf("realms: the realm %q is listed more than once", realm.AsString())
+		}
+		seenRealms[realm.AsString()] = true
+	}
+
+	return nil
+}
+
+// isOptionSet returns true if the option has a value in the config,
+// even if that value won't be known until apply time.
+func isOptionSet(options cty.Value, name string) bool {
+	option := options.GetAttr(name)
+	if !option.IsKnown() {
+		return true
+	}
+
+	if option.IsNull() {
+		return false
+	}
+
+	switch {
+	case option.Type() == cty.String:
+		return option.AsString() != ""
+	case option.Type().IsListType(), option.Type().IsSetType():
+		return option.LengthInt() > 0
+	default:
+		return true
+	}
+}
+
+// validateIPAddressOrCIDR accepts both single IP addresses and CIDR ranges.
+func validateIPAddressOrCIDR(i interface{}, k string) ([]string, []error) {
+	if _, errs := validation.IsIPAddress(i, k); len(errs) == 0 {
+		return nil, nil
+	}
+
+	if _, errs := validation.IsCIDR(i, k); len(errs) == 0 {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("expected %s to be a valid IP address or CIDR range, got: %v", k, i)}
+}
+
+// domainNamePattern matches domain names made of at least two labels of up to 63
+// letters, digits or hyphens, which can't start or end a label.
+var domainNamePattern = regexp.MustCompile(
+	`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`,
+)
+
+// validateDomainName accepts the domain names that Home Realm Discovery
+// can match against the domain of the email of a user.
+func validateDomainName(i interface{}, k string) ([]string, []error) {
+	domain, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if len(domain) > 253 || !domainNamePattern.MatchString(domain) {
+		return nil, []error{fmt.Errorf("expected %s to be a valid domain name, got: %v", k, i)}
+	}
+
+	return nil, nil
+}
+
+// checkIdpInitiatedClientExists ensures the client referenced by the IdP-Initiated
+// login settings of a SAML connection exists, as a typo would silently break it.
+func checkIdpInitiatedClientExists(
+	ctx context.Context,
+	api *management.Management,
+	connection *management.Connection,
+) diag.Diagnostics {
+	options, ok := connection.Options.(*management.ConnectionOptionsSAML)
+	if !ok || options.IdpInitiated == nil || options.IdpInitiated.GetClientID() == "" {
+		return nil
+	}
+
+	clientID := options.IdpInitiated.GetClientID()
+	clientIDPath := cty.Path{
+		cty.GetAttrStep{Name: "options"},
+		cty.IndexStep{Key: cty.NumberIntVal(0)},
+		cty.GetAttrStep{Name: "idp_initiated"},
+		cty.IndexStep{Key: cty.NumberIntVal(0)},
+		cty.GetAttrStep{Name: "client_id"},
+	}
+
+	if _, err := api.Client.Read(clientID, management.Context(ctx)); err != nil {
+		if internalError.IsStatusNotFound(err) {
+			return diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "IdP-Initiated Client Not Found",
+					Detail: fmt.Sprintf(
+						"The client %q referenced by options.0.idp_initiated.0.client_id does not exist.",
+						clientID,
+					),
+					AttributePath: clientIDPath,
+				},
+			}
+		}
+
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Failed To Read IdP-Initiated Client",
+				Detail:        fmt.Sprintf("Failed to read the client with ID %q: %s", clientID, err),
+				AttributePath: clientIDPath,
+			},
+		}
+	}
+
+	return nil
+}
+
+// checkRealmsNotUsedByOtherConnections ensures none of the realms of the
+// connection is already used by another connection of the tenant.
+func checkRealmsNotUsedByOtherConnections(
+	ctx context.Context,
+	api *management.Management,
+	connectionID string,
+	connection *management.Connection,
+) diag.Diagnostics {
+	if len(connection.GetRealms()) == 0 {
+		return nil
+	}
+
+	realms := make(map[string]bool)
+	for _, realm := range connection.GetRealms() {
+		realms[realm] = true
+	}
+
+	connections, err := listAllConnections(ctx, api, management.IncludeFields("id", "name", "realms"))
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Failed To List Connections",
+				Detail:        fmt.Sprintf("Failed to list the connections to check the realms: %s", err),
+				AttributePath: cty.GetAttrPath("realms"),
+			},
+		}
+	}
+
+	var diagnostics diag.Diagnostics
+	for _, otherConnection := range connections {
+		if otherConnection.GetID() == connectionID {
+			continue
+		}
+
+		for _, realm := range otherConnection.GetRealms() {
+			if !realms[realm] {
+				continue
+			}
+
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Realm Already In Use",
+				Detail: fmt.Sprintf(
+					"The realm %q is already used by the connection %q (%s).",
+					realm,
+					otherConnection.GetName(),
+					otherConnection.GetID(),
+				),
+				AttributePath: cty.GetAttrPath("realms"),
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// checkDisplayNameNotUsedByOtherConnections warns when the display name of the
+// connection is already used by another connection of the tenant, as it makes
+// the connections hard to tell apart on the login screen. It doesn't fail, as
+// duplicated display names can be legitimate.
+func checkDisplayNameNotUsedByOtherConnections(
+	ctx context.Context,
+	api *management.Management,
+	connectionID string,
+	connection *management.Connection,
+) diag.Diagnostics {
+	displayName := connection.GetDisplayName()
+	if displayName == "" {
+		return nil
+	}
+
+	connections, err := listAllConnections(ctx, api, management.IncludeFields("id", "name", "display_name"))
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Warning,
+				Summary:       "Failed To List Connections",
+				Detail:        fmt.Sprintf("Failed to list the connections to check the display name: %s", err),
+				AttributePath: cty.GetAttrPath("display_name"),
+			},
+		}
+	}
+
+	var diagnostics diag.Diagnostics
+	for _, otherConnection := range connections {
+		if otherConnection.GetID() == connectionID || otherConnection.GetDisplayName() != displayName {
+			continue
+		}
+
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Display Name Already In Use",
+			Detail: fmt.Sprintf(
+				"The display name %q is already used by the connection %q (%s).",
+				displayName,
+				otherConnection.GetName(),
+				otherConnection.GetID(),
+			),
+			AttributePath: cty.GetAttrPath("display_name"),
+		})
+	}
+
+	return diagnostics
+}
+
+const (
+	maxMetadataKeys        = 10
+	maxMetadataKeyLength   = 255
+	maxMetadataValueLength = 255
+)
+
+// invalidMetadataKeyCharacters can't be used in metadata keys,
+// as the API rejects them.
+const invalidMetadataKeyCharacters = ".$"
+
+// jsonMetadataKeySuffix marks the metadata keys holding structured data
+// serialized as JSON, so that it can be round-tripped by tooling.
+const jsonMetadataKeySuffix = "_json"
+
+func validateConnectionMetadata() schema.SchemaValidateDiagFunc {
+	return func(rawMetadata interface{}, path cty.Path) diag.Diagnostics {
+		var diagnostics diag.Diagnostics
+
+		metadata := rawMetadata.(map[string]interface{})
+		if len(metadata) > maxMetadataKeys {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Too many metadata keys",
+				Detail:        fmt.Sprintf("At most %d metadata keys are allowed, got %d.", maxMetadataKeys, len(metadata)),
+				AttributePath: path,
+			})
+		}
+
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := append(path.Copy(), cty.IndexStep{Key: cty.StringVal(key)})
+
+			diagnostics = append(diagnostics, checkMetadataKey(key, keyPath)...)
+
+			metadataValue, ok := metadata[key].(string)
+			if !ok {
+				continue
+			}
+
+			if strings.HasSuffix(key, jsonMetadataKeySuffix) {
+				if _, errs := validation.StringIsJSON(metadataValue, key); len(errs) > 0 {
+					diagnostics = append(diagnostics, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "Invalid JSON metadata value",
+						Detail: fmt.Sprintf(
+							"The value of the metadata key %q must be valid JSON, as the key ends with %q: %s",
+							key,
+							jsonMetadataKeySuffix,
+							errs[0],
+						),
+						AttributePath: keyPath,
+					})
+				}
+			}
+
+			if len(metadataValue) <= maxMetadataValueLength {
+				continue
+			}
+
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Metadata value too long",
+				Detail: fmt.Sprintf(
+					"The value of the metadata key %q must be at most %d characters long, got %d.",
+					key,
+					maxMetadataValueLength,
+					len(metadataValue),
+				),
+				AttributePath: keyPath,
+			})
+		}
+
+		return diagnostics
+	}
+}
+
+func checkMetadataKey(key string, keyPath cty.Path) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if strings.ContainsAny(key, invalidMetadataKeyCharacters) {
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid metadata key",
+			Detail: fmt.Sprintf(
+				"The metadata key %q must not contain any of the %q characters.",
+				key,
+				invalidMetadataKeyCharacters,
+			),
+			AttributePath: keyPath,
+		})
+	}
+
+	if len(key) > maxMetadataKeyLength {
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Metadata key too long",
+			Detail: fmt.Sprintf(
+				"The metadata key %q must be at most %d characters long, got %d.",
+				key,
+				maxMetadataKeyLength,
+				len(key),
+			),
+			AttributePath: keyPath,
+		})
+	}
+
+	return diagnostics
+}
+
+// emailAuthParamsResponseTypes are the values of the response_type
+// auth param that can be combined, separated by spaces.
+var emailAuthParamsResponseTypes = []string{"code", "token", "id_token"}
+
+func validateConnectionEmailAuthParams() schema.SchemaValidateDiagFunc {
+	return func(rawAuthParams interface{}, path cty.Path) diag.Diagnostics {
+		authParams := rawAuthParams.(map[string]interface{})
+
+		keys := make([]string, 0, len(authParams))
+		for key := range authParams {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var diagnostics diag.Diagnostics
+		for _, key := range keys {
+			keyPath := append(path.Copy(), cty.IndexStep{Key: cty.StringVal(key)})
+
+			switch key {
+			case "scope":
+				continue
+			case "response_type":
+				responseType, _ := authParams[key].(string)
+				if isValidEmailAuthParamsResponseType(responseType) {
+					continue
+				}
+
+				diagnostics = append(diagnostics, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Invalid auth_params response_type",
+					Detail: fmt.Sprintf(
+						"The response_type %q is not supported, it must be a space separated list of: %s.",
+						responseType,
+						strings.Join(emailAuthParamsResponseTypes, ", "),
+					),
+					AttributePath: keyPath,
+				})
+			default:
+				diagnostics = append(diagnostics, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Unknown auth_params key",
+					Detail: fmt.Sprintf(
+						"The auth_params key %q is not used by passwordless email connections and will be ignored. "+
+							"Only \"scope\" and \"response_type\" are supported.",
+						key,
+					),
+					AttributePath: keyPath,
+				})
+			}
+		}
+
+		return diagnostics
+	}
+}
+
+func isValidEmailAuthParamsResponseType(responseType string) bool {
+	responseTypes := strings.Fields(responseType)
+	if len(responseTypes) == 0 {
+		return false
+	}
+
+	for _, responseType := range responseTypes {
+		valid := false
+		for _, validResponseType := range emailAuthParamsResponseTypes {
+			if responseType == validResponseType {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateConnectionUpstreamParams() schema.SchemaValidateDiagFunc {
+	return func(rawUpstreamParams interface{}, path cty.Path) diag.Diagnostics {
+		upstreamParamsJSON, ok := rawUpstreamParams.(string)
+		if !ok || upstreamParamsJSON == "" {
+			return nil
+		}
+
+		if _, errs := validation.StringIsJSON(upstreamParamsJSON, "upstream_params"); len(errs) > 0 {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid upstream_params",
+					Detail:        errs[0].Error(),
+					AttributePath: path,
+				},
+			}
+		}
+
+		var upstreamParams map[string]interface{}
+		if err := json.Unmarshal([]byte(upstreamParamsJSON), &upstreamParams); err != nil {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid upstream_params",
+					Detail:        "The upstream_params must be a JSON object mapping parameter names to an object with an \"alias\" or a \"value\".",
+					AttributePath: path,
+				},
+			}
+		}
+
+		keys := make([]string, 0, len(upstreamParams))
+		for key := range upstreamParams {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var diagnostics diag.Diagnostics
+		for _, key := range keys {
+			if err := checkUpstreamParam(upstreamParams[key]); err != nil {
+				diagnostics = append(diagnostics, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid upstream_params parameter",
+					Detail:        fmt.Sprintf("The upstream_params parameter %q is invalid: %s.", key, err),
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal(key)}),
+				})
+			}
+		}
+
+		return diagnostics
+	}
+}
+
+// checkUpstreamParam checks that an upstream param is an object with either
+// an "alias" (dynamic per user) or a "value" (static per connection).
+func checkUpstreamParam(rawParam interface{}) error {
+	param, ok := rawParam.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("it must be an object with an \"alias\" or a \"value\"")
+	}
+
+	_, hasAlias := param["alias"]
+	_, hasValue := param["value"]
+	if hasAlias == hasValue || len(param) > 1 {
+		return fmt.Errorf("it must have exactly one of \"alias\" or \"value\" and no other keys")
+	}
+
+	key := "value"
+	if hasAlias {
+		key = "alias"
+	}
+
+	if _, ok := param[key].(string); !ok {
+		return fmt.Errorf("the %q must be a string", key)
+	}
+
+	return nil
+}
+
+func validateConnectionFieldsMap() schema.SchemaValidateDiagFunc {
+	return func(rawFieldsMap interface{}, path cty.Path) diag.Diagnostics {
+		fieldsMapJSON, ok := rawFieldsMap.(string)
+		if !ok || fieldsMapJSON == "" {
+			return nil
+		}
+
+		if _, errs := validation.StringIsJSON(fieldsMapJSON, "fields_map"); len(errs) > 0 {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map",
+					Detail:        errs[0].Error(),
+					AttributePath: path,
+				},
+			}
+		}
+
+		var fieldsMap map[string]interface{}
+		if err := json.Unmarshal([]byte(fieldsMapJSON), &fieldsMap); err != nil {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map",
+					Detail:        "The fields_map must be a JSON object mapping attribute names to a string or an array of strings.",
+					AttributePath: path,
+				},
+			}
+		}
+
+		keys := make([]string, 0, len(fieldsMap))
+		for key := range fieldsMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var diagnostics diag.Diagnostics
+		for _, key := range keys {
+			if isValidFieldsMapValue(fieldsMap[key]) {
+				continue
+			}
+
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid fields_map mapping",
+				Detail: fmt.Sprintf(
+					"The fields_map attribute %q must map to a string or an array of strings.",
+					key,
+				),
+				AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal(key)}),
+			})
+		}
+
+		return diagnostics
+	}
+}
+
+func isValidFieldsMapValue(rawValue interface{}) bool {
+	switch fieldsMapValue := rawValue.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, element := range fieldsMapValue {
+			if _, ok := element.(string); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIdpInitiatedClientExists(t *testing.T) {
+	clientIDPath := cty.Path{
+		cty.GetAttrStep{Name: "options"},
+		cty.IndexStep{Key: cty.NumberIntVal(0)},
+		cty.GetAttrStep{Name: "idp_initiated"},
+		cty.IndexStep{Key: cty.NumberIntVal(0)},
+		cty.GetAttrStep{Name: "client_id"},
+	}
+
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/api/v2/clients/existing-client" {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"statusCode":404,"message":"The client does not exist"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"client_id": "existing-client",
+			"name":      "My App",
+		})
+		require.NoError(t, err)
+	})
+
+	var testCases = []struct {
+		name                string
+		givenOptions        interface{}
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "it skips non SAML connections",
+			givenOptions:        &management.ConnectionOptionsOIDC{},
+			expectedDiagnostics: nil,
+		},
+		{
+			name:                "it skips SAML connections without IdP-Initiated settings",
+			givenOptions:        &management.ConnectionOptionsSAML{},
+			expectedDiagnostics: nil,
+		},
+		{
+			name: "it accepts an existing client",
+			givenOptions: &management.ConnectionOptionsSAML{
+				IdpInitiated: &management.ConnectionOptionsSAMLIdpInitiated{
+					ClientID: auth0.String("existing-client"),
+				},
+			},
+			expectedDiagnostics: nil,
+		},
+		{
+			name: "it fails on a missing client",
+			givenOptions: &management.ConnectionOptionsSAML{
+				IdpInitiated: &management.ConnectionOptionsSAMLIdpInitiated{
+					ClientID: auth0.String("misspelled-client"),
+				},
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "IdP-Initiated Client Not Found",
+					Detail:        "The client \"misspelled-client\" referenced by options.0.idp_initiated.0.client_id does not exist.",
+					AttributePath: clientIDPath,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := checkIdpInitiatedClientExists(
+				context.Background(),
+				api,
+				&management.Connection{Options: testCase.givenOptions},
+			)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestCheckRealmsNotUsedByOtherConnections(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"start":  0,
+			"limit":  50,
+			"length": 2,
+			"total":  2,
+			"connections": []map[string]interface{}{
+				{"id": "con_123", "name": "Acme", "realms": []string{"acme.com"}},
+				{"id": "con_456", "name": "Example", "realms": []string{"example.com"}},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	var testCases = []struct {
+		name                string
+		givenConnectionID   string
+		givenRealms         []string
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "it skips connections without realms",
+			givenConnectionID:   "",
+			givenRealms:         nil,
+			expectedDiagnostics: nil,
+		},
+		{
+			name:                "it accepts unused realms",
+			givenConnectionID:   "",
+			givenRealms:         []string{"acme.org"},
+			expectedDiagnostics: nil,
+		},
+		{
+			name:                "it accepts the realms of the connection itself",
+			givenConnectionID:   "con_123",
+			givenRealms:         []string{"acme.com"},
+			expectedDiagnostics: nil,
+		},
+		{
+			name:              "it fails on a realm used by another connection",
+			givenConnectionID: "con_123",
+			givenRealms:       []string{"acme.com", "example.com"},
+			expectedDiagnostics: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Realm Already In Use",
+					Detail:        "The realm \"example.com\" is already used by the connection \"Example\" (con_456).",
+					AttributePath: cty.GetAttrPath("realms"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			connection := &management.Connection{}
+			if testCase.givenRealms != nil {
+				connection.Realms = &testCase.givenRealms
+			}
+
+			actualDiagnostics := checkRealmsNotUsedByOtherConnections(
+				context.Background(),
+				api,
+				testCase.givenConnectionID,
+				connection,
+			)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestCheckDisplayNameNotUsedByOtherConnections(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/connections", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"start":  0,
+			"limit":  50,
+			"length": 2,
+			"total":  2,
+			"connections": []map[string]interface{}{
+				{"id": "con_123", "name": "acme", "display_name": "Acme"},
+				{"id": "con_456", "name": "example", "display_name": "Example"},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	var testCases = []struct {
+		name                string
+		givenConnectionID   string
+		givenDisplayName    string
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "it skips connections without display name",
+			givenConnectionID:   "",
+			givenDisplayName:    "",
+			expectedDiagnostics: nil,
+		},
+		{
+			name:                "it accepts an unused display name",
+			givenConnectionID:   "",
+			givenDisplayName:    "Acme Corp",
+			expectedDiagnostics: nil,
+		},
+		{
+			name:                "it accepts the display name of the connection itself",
+			givenConnectionID:   "con_123",
+			givenDisplayName:    "Acme",
+			expectedDiagnostics: nil,
+		},
+		{
+			name:              "it warns on a display name used by another connection",
+			givenConnectionID: "con_123",
+			givenDisplayName:  "Example",
+			expectedDiagnostics: diag.Diagnostics{
+				{
+					Severity:      diag.Warning,
+					Summary:       "Display Name Already In Use",
+					Detail:        "The display name \"Example\" is already used by the connection \"example\" (con_456).",
+					AttributePath: cty.GetAttrPath("display_name"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			connection := &management.Connection{}
+			if testCase.givenDisplayName != "" {
+				connection.DisplayName = &testCase.givenDisplayName
+			}
+
+			actualDiagnostics := checkDisplayNameNotUsedByOtherConnections(
+				context.Background(),
+				api,
+				testCase.givenConnectionID,
+				connection,
+			)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestCheckDisplayNameNotUsedByOtherConnectionsWarnsWhenListingFails(t *testing.T) {
+	api := newStubManagementAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, err := w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Insufficient scope"}`))
+		require.NoError(t, err)
+	})
+
+	displayName := "Acme"
+	actualDiagnostics := checkDisplayNameNotUsedByOtherConnections(
+		context.Background(),
+		api,
+		"",
+		&management.Connection{DisplayName: &displayName},
+	)
+
+	require.Len(t, actualDiagnostics, 1)
+	assert.Equal(t, diag.Warning, actualDiagnostics[0].Severity)
+	assert.Equal(t, "Failed To List Connections", actualDiagnostics[0].Summary)
+}
@@ -0,0 +1,1353 @@
+package connection
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRawOptions builds the raw config of the options block
+// with the given values and every other option left unset.
+func newRawOptions(t *testing.T, values map[string]cty.Value) cty.Value {
+	t.Helper()
+
+	optionsType := resourceSchema["options"].Elem.(*schema.Resource).CoreConfigSchema().ImpliedType()
+
+	attributes := make(map[string]cty.Value)
+	for name, attributeType := range optionsType.AttributeTypes() {
+		attributes[name] = cty.NullVal(attributeType)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return cty.ListVal([]cty.Value{cty.ObjectVal(attributes)})
+}
+
+func TestCheckStrategySpecificOptions(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenStrategy string
+		givenOptions  cty.Value
+		expectedError string
+	}{
+		{
+			name:          "no options are set",
+			givenStrategy: "google-oauth2",
+			givenOptions:  cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name:          "twilio options on an sms connection",
+			givenStrategy: "sms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"twilio_sid":   cty.StringVal("ABC"),
+				"twilio_token": cty.StringVal("DEF"),
+				"from":         cty.StringVal("+12345678"),
+			}),
+		},
+		{
+			name:          "from and template on an email connection",
+			givenStrategy: "email",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"from":     cty.StringVal("magic-link@example.com"),
+				"template": cty.StringVal("<html></html>"),
+			}),
+		},
+		{
+			name:          "saml options on a samlp connection",
+			givenStrategy: "samlp",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"signing_cert": cty.StringVal("cert"),
+				"debug":        cty.False,
+			}),
+		},
+		{
+			name:          "empty blocks are not considered set",
+			givenStrategy: "google-oauth2",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"totp": cty.ListValEmpty(cty.Object(map[string]cty.Type{
+					"time_step": cty.Number,
+					"length":    cty.Number,
+				})),
+			}),
+		},
+		{
+			name:          "twilio option on a google-oauth2 connection",
+			givenStrategy: "google-oauth2",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"twilio_sid": cty.StringVal("ABC"),
+			}),
+			expectedError: "options.0.twilio_sid: this option is only supported by connections with " +
+				`the "sms" strategy, but the connection uses the "google-oauth2" strategy`,
+		},
+		{
+			name:          "from option on an auth0 connection",
+			givenStrategy: "auth0",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"from": cty.StringVal("+12345678"),
+			}),
+			expectedError: "options.0.from: this option is only supported by connections with " +
+				`the "sms" or "email" strategy, but the connection uses the "auth0" strategy`,
+		},
+		{
+			name:          "saml option on a waad connection",
+			givenStrategy: "waad",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"metadata_url": cty.StringVal("https://saml.provider/metadata.xml"),
+			}),
+			expectedError: "options.0.metadata_url: this option is only supported by connections with " +
+				`the "samlp" strategy, but the connection uses the "waad" strategy`,
+		},
+		{
+			name:          "ordered scope on a github connection",
+			givenStrategy: "github",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"scope": cty.ListVal([]cty.Value{cty.StringVal("read:user")}),
+			}),
+			expectedError: "options.0.scope: this option is only supported by connections with " +
+				`the "oauth2" or "oidc" strategy, but the connection uses the "github" strategy`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkStrategySpecificOptions(testCase.givenStrategy, testCase.givenOptions)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckSAMLConnectionOptions(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError error
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name: "sign_in_endpoint with signing_cert",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal("https://saml.provider/sign_in"),
+				"signing_cert":     cty.StringVal("cert"),
+				"protocol_binding": cty.StringVal("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+			}),
+		},
+		{
+			name: "sign_in_endpoint with metadata_url",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal("https://saml.provider/sign_in"),
+				"metadata_url":     cty.StringVal("https://saml.provider/metadata.xml"),
+			}),
+		},
+		{
+			name: "sign_in_endpoint with metadata_xml",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal("https://saml.provider/sign_in"),
+				"metadata_xml":     cty.StringVal("<xml/>"),
+			}),
+		},
+		{
+			name: "sign_in_endpoint with a signing_cert known only after apply",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal("https://saml.provider/sign_in"),
+				"signing_cert":     cty.UnknownVal(cty.String),
+			}),
+		},
+		{
+			name: "sign_in_endpoint without signing_cert or metadata",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal("https://saml.provider/sign_in"),
+			}),
+			expectedError: errSAMLSignInEndpointWithoutCert,
+		},
+		{
+			name: "protocol_binding without sign_in_endpoint",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"signing_cert":     cty.StringVal("cert"),
+				"protocol_binding": cty.StringVal("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+			}),
+			expectedError: errSAMLProtocolBindingWithoutSignInEndpoint,
+		},
+		{
+			name: "protocol_binding with an empty sign_in_endpoint",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_in_endpoint": cty.StringVal(""),
+				"protocol_binding": cty.StringVal("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+			}),
+			expectedError: errSAMLProtocolBindingWithoutSignInEndpoint,
+		},
+		{
+			name: "sign_saml_request with both algorithms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_saml_request":   cty.True,
+				"signature_algorithm": cty.StringVal("rsa-sha256"),
+				"digest_algorithm":    cty.StringVal("sha256"),
+			}),
+		},
+		{
+			name: "sign_saml_request disabled without algorithms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_saml_request": cty.False,
+			}),
+		},
+		{
+			name: "sign_saml_request without algorithms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_saml_request": cty.True,
+			}),
+			expectedError: errSAMLSignRequestWithoutAlgorithms,
+		},
+		{
+			name: "sign_saml_request without digest_algorithm",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"sign_saml_request":   cty.True,
+				"signature_algorithm": cty.StringVal("rsa-sha1"),
+			}),
+			expectedError: errSAMLSignRequestWithoutAlgorithms,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkSAMLConnectionOptions(testCase.givenOptions)
+
+			if testCase.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestChangedOptionsRequiringReplacement(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		strategy       string
+		changedKeys    []string
+		expectedResult []string
+	}{
+		{
+			name:           "waad identity_api changed",
+			strategy:       "waad",
+			changedKeys:    []string{"options.0.identity_api", "options.0.client_id"},
+			expectedResult: []string{"options.0.identity_api"},
+		},
+		{
+			name:           "waad identity_api unchanged",
+			strategy:       "waad",
+			changedKeys:    []string{"options.0.client_id"},
+			expectedResult: nil,
+		},
+		{
+			name:           "strategy without options requiring replacement",
+			strategy:       "auth0",
+			changedKeys:    []string{"options.0.identity_api"},
+			expectedResult: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			hasChange := func(key string) bool {
+				for _, changedKey := range testCase.changedKeys {
+					if key == changedKey {
+						return true
+					}
+				}
+				return false
+			}
+
+			actual := changedOptionsRequiringReplacement(testCase.strategy, hasChange)
+			assert.Equal(t, testCase.expectedResult, actual)
+		})
+	}
+}
+
+func TestConnectionDiffRequiresNew(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenName           string
+		givenEnabledClients []string
+		expectedRequiresNew bool
+	}{
+		{
+			name:                "only the enabled clients change",
+			givenName:           "Acme",
+			givenEnabledClients: []string{"client_1", "client_2"},
+			expectedRequiresNew: false,
+		},
+		{
+			name:                "the name changes",
+			givenName:           "Acme Corp",
+			givenEnabledClients: []string{"client_1"},
+			expectedRequiresNew: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resource := NewResource()
+
+			state := &terraform.InstanceState{
+				ID: "con_123",
+				Attributes: map[string]string{
+					"id":                "con_123",
+					"name":              "Acme",
+					"strategy":          "auth0",
+					"enabled_clients.#": "1",
+					"enabled_clients." + strconv.Itoa(schema.HashString("client_1")): "client_1",
+					// The computed attributes as read back from the API.
+					"options.#":      "0",
+					"realms.#":       "1",
+					"realms.0":       "Acme",
+					"signing_keys.#": "0",
+				},
+			}
+
+			enabledClients := make([]interface{}, 0, len(testCase.givenEnabledClients))
+			for _, clientID := range testCase.givenEnabledClients {
+				enabledClients = append(enabledClients, clientID)
+			}
+			config := map[string]interface{}{
+				"name":            testCase.givenName,
+				"strategy":        "auth0",
+				"enabled_clients": enabledClients,
+			}
+
+			// The plan time checks read the raw config, which
+			// Terraform would otherwise send alongside the state.
+			state.RawConfig = newRawConfig(t, resource, map[string]cty.Value{
+				"name":            cty.StringVal(testCase.givenName),
+				"strategy":        cty.StringVal("auth0"),
+				"enabled_clients": toStringSetVal(testCase.givenEnabledClients),
+			})
+
+			diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+
+			assert.Equal(t, testCase.expectedRequiresNew, diff.RequiresNew())
+			if !testCase.expectedRequiresNew {
+				for key := range diff.Attributes {
+					assert.Truef(t, strings.HasPrefix(key, "enabled_clients."), "Expected only enabled_clients to change, got %s", key)
+				}
+			}
+		})
+	}
+}
+
+// newRawConfig builds the raw config of the resource
+// with the given values and every other attribute left unset.
+func newRawConfig(t *testing.T, resource *schema.Resource, values map[string]cty.Value) cty.Value {
+	t.Helper()
+
+	attributes := make(map[string]cty.Value)
+	for name, attributeType := range resource.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		attributes[name] = cty.NullVal(attributeType)
+	}
+
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return cty.ObjectVal(attributes)
+}
+
+func toStringSetVal(values []string) cty.Value {
+	elements := make([]cty.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, cty.StringVal(value))
+	}
+
+	return cty.SetVal(elements)
+}
+
+func TestCheckAppleConnectionScopes(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError string
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name:         "no scopes are set",
+			givenOptions: newRawOptions(t, map[string]cty.Value{}),
+		},
+		{
+			name: "name and email scopes",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"scopes": cty.SetVal([]cty.Value{cty.StringVal("name"), cty.StringVal("email")}),
+			}),
+		},
+		{
+			name: "unsupported scope",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"scopes": cty.SetVal([]cty.Value{cty.StringVal("email"), cty.StringVal("openid")}),
+			}),
+			expectedError: `options.0.scopes: "openid" is not supported by connections with the "apple" strategy, ` +
+				`expected one of ["email" "name"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkAppleConnectionScopes(testCase.givenOptions)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestValidateIPAddressOrCIDR(t *testing.T) {
+	var testCases = []struct {
+		givenValue    string
+		expectedValid bool
+	}{
+		{givenValue: "192.168.1.1", expectedValid: true},
+		{givenValue: "2001:db8::1", expectedValid: true},
+		{givenValue: "10.0.0.0/8", expectedValid: true},
+		{givenValue: "2001:db8::/32", expectedValid: true},
+		{givenValue: "10.0.0.0/33", expectedValid: false},
+		{givenValue: "192.168.1.256", expectedValid: false},
+		{givenValue: "not-an-ip", expectedValid: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.givenValue, func(t *testing.T) {
+			_, errs := validateIPAddressOrCIDR(testCase.givenValue, "options.0.ips")
+
+			if testCase.expectedValid {
+				assert.Empty(t, errs)
+				return
+			}
+
+			assert.EqualError(
+				t,
+				errs[0],
+				"expected options.0.ips to be a valid IP address or CIDR range, got: "+testCase.givenValue,
+			)
+		})
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	var testCases = []struct {
+		givenValue    string
+		expectedValid bool
+	}{
+		{givenValue: "example.com", expectedValid: true},
+		{givenValue: "api.example.com", expectedValid: true},
+		{givenValue: "my-company.co.uk", expectedValid: true},
+		{givenValue: "xn--bcher-kva.example", expectedValid: true},
+		{givenValue: "localhost", expectedValid: false},
+		{givenValue: "-example.com", expectedValid: false},
+		{givenValue: "example-.com", expectedValid: false},
+		{givenValue: "example..com", expectedValid: false},
+		{givenValue: "https://example.com", expectedValid: false},
+		{givenValue: "user@example.com", expectedValid: false},
+		{givenValue: "example.com ", expectedValid: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.givenValue, func(t *testing.T) {
+			_, errs := validateDomainName(testCase.givenValue, "options.0.domain_aliases")
+
+			if testCase.expectedValid {
+				assert.Empty(t, errs)
+				return
+			}
+
+			assert.EqualError(
+				t,
+				errs[0],
+				"expected options.0.domain_aliases to be a valid domain name, got: "+testCase.givenValue,
+			)
+		})
+	}
+}
+
+func TestCheckShowAsButton(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenStrategy     string
+		givenShowAsButton cty.Value
+		expectedError     string
+	}{
+		{
+			name:              "enterprise connection",
+			givenStrategy:     "samlp",
+			givenShowAsButton: cty.True,
+		},
+		{
+			name:              "database connection without show_as_button",
+			givenStrategy:     "auth0",
+			givenShowAsButton: cty.NullVal(cty.Bool),
+		},
+		{
+			name:              "database connection with show_as_button",
+			givenStrategy:     "auth0",
+			givenShowAsButton: cty.False,
+			expectedError: `show_as_button: this attribute is only supported by enterprise connections ` +
+				`(ad, adfs, auth0-adldap, custom, google-apps, ip, office365, oidc, okta, pingfederate, ` +
+				`samlp, sharepoint, waad), but the connection uses the "auth0" strategy`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkShowAsButton(testCase.givenStrategy, testCase.givenShowAsButton)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckOktaConnectionEndpoints(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError string
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name: "endpoints are discovered through the discovery url",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"discovery_url": cty.StringVal("https://domain.okta.com/.well-known/openid-configuration"),
+			}),
+		},
+		{
+			name: "endpoints are discovered through the domain",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"domain": cty.StringVal("domain.okta.com"),
+			}),
+		},
+		{
+			name: "all endpoints are set manually",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"domain":                 cty.StringVal("domain.okta.com"),
+				"issuer":                 cty.StringVal("https://domain.okta.com"),
+				"jwks_uri":               cty.StringVal("https://domain.okta.com/oauth2/v1/keys"),
+				"token_endpoint":         cty.StringVal("https://domain.okta.com/oauth2/v1/token"),
+				"userinfo_endpoint":      cty.StringVal("https://domain.okta.com/oauth2/v1/userinfo"),
+				"authorization_endpoint": cty.StringVal("https://domain.okta.com/oauth2/v1/authorize"),
+			}),
+		},
+		{
+			name: "some endpoints are set manually",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"domain":         cty.StringVal("domain.okta.com"),
+				"issuer":         cty.StringVal("https://domain.okta.com"),
+				"token_endpoint": cty.StringVal("https://domain.okta.com/oauth2/v1/token"),
+			}),
+			expectedError: "options.0: connections with the \"okta\" strategy need either `discovery_url` or all of " +
+				"the endpoints to be set, missing: authorization_endpoint, jwks_uri, userinfo_endpoint",
+		},
+		{
+			name:         "nothing to discover the endpoints from",
+			givenOptions: newRawOptions(t, map[string]cty.Value{}),
+			expectedError: "options.0: connections with the \"okta\" strategy need either `discovery_url` or all of " +
+				"the endpoints to be set, missing: authorization_endpoint, issuer, jwks_uri, token_endpoint, userinfo_endpoint",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkOktaConnectionEndpoints(testCase.givenOptions)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckDatabaseCustomization(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError error
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name: "customization is disabled",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.False,
+			}),
+		},
+		{
+			name: "customization is enabled with a login script",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.True,
+				"custom_scripts": cty.MapVal(map[string]cty.Value{
+					"login": cty.StringVal("function login(email, password, callback) {}"),
+				}),
+			}),
+		},
+		{
+			name: "customization is enabled with a login script known only after apply",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.True,
+				"custom_scripts": cty.MapVal(map[string]cty.Value{
+					"login": cty.UnknownVal(cty.String),
+				}),
+			}),
+		},
+		{
+			name: "customization is enabled without custom scripts",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.True,
+			}),
+			expectedError: errDatabaseCustomizationWithoutLoginScript,
+		},
+		{
+			name: "customization is enabled without a login script",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.True,
+				"custom_scripts": cty.MapVal(map[string]cty.Value{
+					"get_user": cty.StringVal("function getByEmail(email, callback) {}"),
+				}),
+			}),
+			expectedError: errDatabaseCustomizationWithoutLoginScript,
+		},
+		{
+			name: "customization is enabled with an empty login script",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"enabled_database_customization": cty.True,
+				"custom_scripts": cty.MapVal(map[string]cty.Value{
+					"login": cty.StringVal(""),
+				}),
+			}),
+			expectedError: errDatabaseCustomizationWithoutLoginScript,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkDatabaseCustomization(testCase.givenOptions)
+
+			if testCase.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckSocialClientCredentials(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError string
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name:         "neither credential is set to use the developer keys",
+			givenOptions: newRawOptions(t, map[string]cty.Value{}),
+		},
+		{
+			name: "both credentials are set to use custom keys",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"client_id":     cty.StringVal("client-id"),
+				"client_secret": cty.StringVal("client-secret"),
+			}),
+		},
+		{
+			name: "client secret is known only after apply",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"client_id":     cty.StringVal("client-id"),
+				"client_secret": cty.UnknownVal(cty.String),
+			}),
+		},
+		{
+			name: "only the client id is set",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"client_id": cty.StringVal("client-id"),
+			}),
+			expectedError: "options.0.client_id: `options.0.client_secret` must also be specified when `client_id` " +
+				"is set on a connection with the \"google-oauth2\" strategy. " +
+				"Set both to use custom keys, or neither to use the Auth0 developer keys",
+		},
+		{
+			name: "only the client secret is set",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"client_id":     cty.StringVal(""),
+				"client_secret": cty.StringVal("client-secret"),
+			}),
+			expectedError: "options.0.client_secret: `options.0.client_id` must also be specified when `client_secret` " +
+				"is set on a connection with the \"google-oauth2\" strategy. " +
+				"Set both to use custom keys, or neither to use the Auth0 developer keys",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkSocialClientCredentials("google-oauth2", testCase.givenOptions)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckSMSGateway(t *testing.T) {
+	gatewayAuthenticationType := resourceSchema["options"].Elem.(*schema.Resource).
+		Schema["gateway_authentication"].Elem.(*schema.Resource).CoreConfigSchema().ImpliedType()
+
+	newGatewayAuthentication := func(values map[string]cty.Value) cty.Value {
+		attributes := make(map[string]cty.Value)
+		for name, attributeType := range gatewayAuthenticationType.AttributeTypes() {
+			attributes[name] = cty.NullVal(attributeType)
+		}
+		for name, value := range values {
+			attributes[name] = value
+		}
+
+		return cty.ListVal([]cty.Value{cty.ObjectVal(attributes)})
+	}
+
+	var testCases = []struct {
+		name          string
+		givenOptions  cty.Value
+		expectedError string
+	}{
+		{
+			name:         "no options are set",
+			givenOptions: cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name:         "twilio is used",
+			givenOptions: newRawOptions(t, map[string]cty.Value{}),
+		},
+		{
+			name: "custom gateway with bearer authentication",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"provider":    cty.StringVal("sms_gateway"),
+				"gateway_url": cty.StringVal("https://somewhere.com/sms-gateway"),
+				"gateway_authentication": newGatewayAuthentication(map[string]cty.Value{
+					"method":   cty.StringVal("bearer"),
+					"subject":  cty.StringVal("test.us.auth0.com:sms"),
+					"audience": cty.StringVal("https://somewhere.com/sms-gateway"),
+					"secret":   cty.StringVal("4e2680bb72ec2ae24836476dd37ed6c2"),
+				}),
+			}),
+		},
+		{
+			name: "custom gateway without gateway_url",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"provider": cty.StringVal("sms_gateway"),
+			}),
+			expectedError: errSMSGatewayWithoutURL.Error(),
+		},
+		{
+			name: "bearer authentication with missing claims",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"provider":    cty.StringVal("sms_gateway"),
+				"gateway_url": cty.StringVal("https://somewhere.com/sms-gateway"),
+				"gateway_authentication": newGatewayAuthentication(map[string]cty.Value{
+					"method":  cty.StringVal("bearer"),
+					"subject": cty.StringVal("test.us.auth0.com:sms"),
+				}),
+			}),
+			expectedError: "options.0.gateway_authentication.0: the \"bearer\" authentication method needs " +
+				"all of the token claims to be set, missing: audience, secret",
+		},
+		{
+			name: "default authentication method with missing claims",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"provider":    cty.StringVal("sms_gateway"),
+				"gateway_url": cty.StringVal("https://somewhere.com/sms-gateway"),
+				"gateway_authentication": newGatewayAuthentication(map[string]cty.Value{
+					"audience": cty.StringVal("https://somewhere.com/sms-gateway"),
+					"secret":   cty.UnknownVal(cty.String),
+				}),
+			}),
+			expectedError: "options.0.gateway_authentication.0: the \"bearer\" authentication method needs " +
+				"all of the token claims to be set, missing: subject",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkSMSGateway(testCase.givenOptions)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckForwardRequestInfo(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenStrategy string
+		givenOptions  cty.Value
+		expectedError error
+	}{
+		{
+			name:          "no options are set",
+			givenStrategy: "sms",
+			givenOptions:  cty.NullVal(cty.List(cty.EmptyObject)),
+		},
+		{
+			name:          "forward_request_info with a custom gateway",
+			givenStrategy: "sms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"forward_request_info": cty.True,
+				"provider":             cty.StringVal("sms_gateway"),
+				"gateway_url":          cty.StringVal("https://somewhere.com/sms-gateway"),
+			}),
+		},
+		{
+			name:          "forward_request_info disabled without a custom gateway",
+			givenStrategy: "sms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"forward_request_info": cty.False,
+			}),
+		},
+		{
+			name:          "forward_request_info with twilio",
+			givenStrategy: "sms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"forward_request_info": cty.True,
+				"twilio_sid":           cty.StringVal("sid"),
+			}),
+			expectedError: errForwardRequestInfoWithoutSMSGateway,
+		},
+		{
+			name:          "forward_request_info with a custom gateway without gateway_url",
+			givenStrategy: "sms",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"forward_request_info": cty.True,
+				"provider":             cty.StringVal("sms_gateway"),
+			}),
+			expectedError: errForwardRequestInfoWithoutSMSGateway,
+		},
+		{
+			name:          "forward_request_info on another strategy",
+			givenStrategy: "email",
+			givenOptions: newRawOptions(t, map[string]cty.Value{
+				"forward_request_info": cty.True,
+			}),
+			expectedError: errForwardRequestInfoWithoutSMSGateway,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkForwardRequestInfo(testCase.givenStrategy, testCase.givenOptions)
+
+			if testCase.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCheckRealms(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		givenRealms   cty.Value
+		expectedError string
+	}{
+		{
+			name:        "no realms are set",
+			givenRealms: cty.NullVal(cty.List(cty.String)),
+		},
+		{
+			name:        "realms are unique",
+			givenRealms: cty.ListVal([]cty.Value{cty.StringVal("acme.com"), cty.StringVal("acme.org")}),
+		},
+		{
+			name:        "realms are known only after apply",
+			givenRealms: cty.ListVal([]cty.Value{cty.UnknownVal(cty.String), cty.UnknownVal(cty.String)}),
+		},
+		{
+			name: "a realm is listed twice",
+			givenRealms: cty.ListVal([]cty.Value{
+				cty.StringVal("acme.com"),
+				cty.StringVal("acme.org"),
+				cty.StringVal("acme.com"),
+			}),
+			expectedError: "realms: the realm \"acme.com\" is listed more than once",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkRealms(testCase.givenRealms)
+
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestValidateConnectionMetadata(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenMetadata       map[string]interface{}
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "metadata is empty",
+			givenMetadata:       map[string]interface{}{},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "metadata value is at the length limit",
+			givenMetadata: map[string]interface{}{
+				"key1": strings.Repeat("a", 255),
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "metadata value exceeds the length limit",
+			givenMetadata: map[string]interface{}{
+				"key1": "foo",
+				"key2": strings.Repeat("a", 256),
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Metadata value too long",
+					Detail:   "The value of the metadata key \"key2\" must be at most 255 characters long, got 256.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal("key2")},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata key contains a dot",
+			givenMetadata: map[string]interface{}{
+				"tier.name": "gold",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid metadata key",
+					Detail:   "The metadata key \"tier.name\" must not contain any of the \".$\" characters.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal("tier.name")},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata key contains a dollar sign",
+			givenMetadata: map[string]interface{}{
+				"$tier": "gold",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid metadata key",
+					Detail:   "The metadata key \"$tier\" must not contain any of the \".$\" characters.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal("$tier")},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata key exceeds the length limit",
+			givenMetadata: map[string]interface{}{
+				strings.Repeat("k", 256): "gold",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Metadata key too long",
+					Detail: "The metadata key \"" + strings.Repeat("k", 256) + "\" must be at most 255 characters long, " +
+						"got 256.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal(strings.Repeat("k", 256))},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata has too many keys",
+			givenMetadata: map[string]interface{}{
+				"key1": "a", "key2": "a", "key3": "a", "key4": "a", "key5": "a", "key6": "a",
+				"key7": "a", "key8": "a", "key9": "a", "key10": "a", "key11": "a",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Too many metadata keys",
+					Detail:        "At most 10 metadata keys are allowed, got 11.",
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "metadata"}},
+				},
+			},
+		},
+		{
+			name: "metadata json value is valid",
+			givenMetadata: map[string]interface{}{
+				"settings_json": `{"tier":"gold","regions":["eu","us"]}`,
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "metadata json value is invalid",
+			givenMetadata: map[string]interface{}{
+				"settings":      `{"not":"checked"`,
+				"settings_json": `{"tier":"gold"`,
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid JSON metadata value",
+					Detail: "The value of the metadata key \"settings_json\" must be valid JSON, as the key ends " +
+						"with \"_json\": \"settings_json\" contains an invalid JSON: unexpected end of JSON input",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal("settings_json")},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata json value exceeds the length limit once serialized",
+			givenMetadata: map[string]interface{}{
+				"settings_json": `{"description":"` + strings.Repeat("a", 240) + `"}`,
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Metadata value too long",
+					Detail:   "The value of the metadata key \"settings_json\" must be at most 255 characters long, got 258.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "metadata"},
+						cty.IndexStep{Key: cty.StringVal("settings_json")},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := validateConnectionMetadata()(
+				testCase.givenMetadata,
+				cty.Path{cty.GetAttrStep{Name: "metadata"}},
+			)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestValidateConnectionMetadataDoesNotAliasThePath(t *testing.T) {
+	// A path with spare capacity would get its key
+	// overwritten if it was shared between the diagnostics.
+	path := make(cty.Path, 0, 4)
+	path = append(path, cty.GetAttrStep{Name: "metadata"})
+
+	diagnostics := validateConnectionMetadata()(
+		map[string]interface{}{
+			"a.tier":  "gold",
+			"b$level": "silver",
+		},
+		path,
+	)
+
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, cty.GetAttrPath("metadata").IndexString("a.tier"), diagnostics[0].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("metadata").IndexString("b$level"), diagnostics[1].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("metadata"), path)
+}
+
+func TestValidateConnectionEmailAuthParams(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		givenAuthParams     map[string]interface{}
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name: "auth params only have known keys",
+			givenAuthParams: map[string]interface{}{
+				"scope":         "openid profile",
+				"response_type": "code",
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "auth params have a combined response type",
+			givenAuthParams: map[string]interface{}{
+				"response_type": "token id_token",
+			},
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name: "auth params have an unknown key",
+			givenAuthParams: map[string]interface{}{
+				"scope":    "openid",
+				"audience": "https://api.example.com",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Unknown auth_params key",
+					Detail: "The auth_params key \"audience\" is not used by passwordless email connections " +
+						"and will be ignored. Only \"scope\" and \"response_type\" are supported.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "auth_params"},
+						cty.IndexStep{Key: cty.StringVal("audience")},
+					},
+				},
+			},
+		},
+		{
+			name: "auth params have an invalid response type",
+			givenAuthParams: map[string]interface{}{
+				"response_type": "code foo",
+			},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Invalid auth_params response_type",
+					Detail:   "The response_type \"code foo\" is not supported, it must be a space separated list of: code, token, id_token.",
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "auth_params"},
+						cty.IndexStep{Key: cty.StringVal("response_type")},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := validateConnectionEmailAuthParams()(
+				testCase.givenAuthParams,
+				cty.Path{cty.GetAttrStep{Name: "auth_params"}},
+			)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestValidateConnectionUpstreamParams(t *testing.T) {
+	path := cty.Path{cty.GetAttrStep{Name: "upstream_params"}}
+
+	var testCases = []struct {
+		name                string
+		givenUpstreamParams string
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "upstream params have an alias and a value",
+			givenUpstreamParams: `{"screen_name":{"alias":"login_hint"},"prompt":{"value":"consent"}}`,
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name:                "upstream params are empty",
+			givenUpstreamParams: `{}`,
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name:                "upstream params are not valid JSON",
+			givenUpstreamParams: `{"screen_name":`,
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid upstream_params",
+					Detail:        "\"upstream_params\" contains an invalid JSON: unexpected end of JSON input",
+					AttributePath: path,
+				},
+			},
+		},
+		{
+			name:                "upstream params are not a JSON object",
+			givenUpstreamParams: `["screen_name"]`,
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid upstream_params",
+					Detail: "The upstream_params must be a JSON object mapping parameter names " +
+						"to an object with an \"alias\" or a \"value\".",
+					AttributePath: path,
+				},
+			},
+		},
+		{
+			name: "upstream params are structurally invalid",
+			givenUpstreamParams: `{
+				"screen_name": "login_hint",
+				"prompt": {"alias":"login_hint","value":"consent"},
+				"display": {"value":true},
+				"login_hint": {"alias":"login_hint"},
+				"ui_locales": {"static":"en"}
+			}`,
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid upstream_params parameter",
+					Detail:        "The upstream_params parameter \"display\" is invalid: the \"value\" must be a string.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("display")}),
+				},
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid upstream_params parameter",
+					Detail: "The upstream_params parameter \"prompt\" is invalid: " +
+						"it must have exactly one of \"alias\" or \"value\" and no other keys.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("prompt")}),
+				},
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid upstream_params parameter",
+					Detail: "The upstream_params parameter \"screen_name\" is invalid: " +
+						"it must be an object with an \"alias\" or a \"value\".",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("screen_name")}),
+				},
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid upstream_params parameter",
+					Detail: "The upstream_params parameter \"ui_locales\" is invalid: " +
+						"it must have exactly one of \"alias\" or \"value\" and no other keys.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("ui_locales")}),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := validateConnectionUpstreamParams()(testCase.givenUpstreamParams, path)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
+
+func TestValidateConnectionFieldsMap(t *testing.T) {
+	path := cty.Path{cty.GetAttrStep{Name: "fields_map"}}
+
+	var testCases = []struct {
+		name                string
+		givenFieldsMap      string
+		expectedDiagnostics diag.Diagnostics
+	}{
+		{
+			name:                "fields map has strings and arrays of strings",
+			givenFieldsMap:      `{"name":["name","nameidentifier"],"email":"emailaddress"}`,
+			expectedDiagnostics: diag.Diagnostics(nil),
+		},
+		{
+			name:           "fields map is not a JSON object",
+			givenFieldsMap: `["name"]`,
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map",
+					Detail:        "The fields_map must be a JSON object mapping attribute names to a string or an array of strings.",
+					AttributePath: path,
+				},
+			},
+		},
+		{
+			name: "fields map has nested objects",
+			givenFieldsMap: `{
+				"email": "emailaddress",
+				"name": {"first":"given_name"},
+				"groups": ["groups", {"name":"group"}],
+				"age": 42
+			}`,
+			expectedDiagnostics: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map mapping",
+					Detail:        "The fields_map attribute \"age\" must map to a string or an array of strings.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("age")}),
+				},
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map mapping",
+					Detail:        "The fields_map attribute \"groups\" must map to a string or an array of strings.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("groups")}),
+				},
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Invalid fields_map mapping",
+					Detail:        "The fields_map attribute \"name\" must map to a string or an array of strings.",
+					AttributePath: append(path.Copy(), cty.IndexStep{Key: cty.StringVal("name")}),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualDiagnostics := validateConnectionFieldsMap()(testCase.givenFieldsMap, path)
+
+			assert.Equal(t, testCase.expectedDiagnostics, actualDiagnostics)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package customdomain
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -10,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -117,7 +117,7 @@ func readCustomDomain(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	customDomain, err := api.CustomDomain.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -148,7 +148,7 @@ func updateCustomDomain(ctx context.Context, d *schema.ResourceData, m interface
 
 	customDomain := expandCustomDomain(d)
 	if err := api.CustomDomain.Update(d.Id(), customDomain); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -162,7 +162,7 @@ func deleteCustomDomain(ctx context.Context, d *schema.ResourceData, m interface
 	api := m.(*management.Management)
 
 	if err := api.CustomDomain.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
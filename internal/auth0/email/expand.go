@@ -1,11 +1,10 @@
 package email
 
 import (
-	"net/http"
-
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -151,7 +150,7 @@ func expandEmailTemplate(config cty.Value) *management.EmailTemplate {
 
 func emailProviderIsConfigured(api *management.Management) bool {
 	_, err := api.EmailProvider.Read()
-	if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+	if internalError.IsStatusNotFound(err) {
 		return false
 	}
 
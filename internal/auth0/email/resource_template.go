@@ -2,7 +2,6 @@ package email
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
@@ -10,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
 // NewTemplateResource will return a new auth0_email_template resource.
@@ -133,7 +134,7 @@ func readEmailTemplate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	email, err := api.EmailTemplate.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -175,11 +176,9 @@ func deleteEmailTemplate(ctx context.Context, d *schema.ResourceData, m interfac
 		Enabled:  auth0.Bool(false),
 	}
 	if err := api.EmailTemplate.Update(d.Id(), emailTemplate); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 	}
 
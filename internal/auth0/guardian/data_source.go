@@ -0,0 +1,85 @@
+package guardian
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalSchema "github.com/auth0/terraform-provider-auth0/internal/schema"
+)
+
+// NewDataSource will return a new auth0_guardian data source.
+func NewDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readGuardianForDataSource,
+		Description: "Use this data source to access information about the tenant's Multi-Factor Authentication " +
+			"policy and factors. Factor secrets, such as the Duo secret key or the Twilio auth token, are not exposed.",
+		Schema: dataSourceSchema(),
+	}
+}
+
+func dataSourceSchema() map[string]*schema.Schema {
+	return internalSchema.TransformResourceToDataSource(NewResource().Schema)
+}
+
+func readGuardianForDataSource(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	data.SetId(resource.UniqueId())
+
+	if diags := readGuardian(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return diag.FromErr(clearFactorSecrets(data))
+}
+
+// clearFactorSecrets blanks the factor secrets returned by the API, as
+// the data source schema can't mark nested attributes as sensitive.
+func clearFactorSecrets(data *schema.ResourceData) error {
+	duo := data.Get("duo").([]interface{})
+	for _, duoSettings := range duo {
+		if duoSettings, ok := duoSettings.(map[string]interface{}); ok {
+			duoSettings["secret_key"] = ""
+		}
+	}
+
+	phone := data.Get("phone").([]interface{})
+	for _, phoneSettings := range phone {
+		phoneSettings, ok := phoneSettings.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		phoneOptions, _ := phoneSettings["options"].([]interface{})
+		for _, options := range phoneOptions {
+			if options, ok := options.(map[string]interface{}); ok {
+				options["auth_token"] = ""
+			}
+		}
+	}
+
+	push := data.Get("push").([]interface{})
+	for _, pushSettings := range push {
+		pushSettings, ok := pushSettings.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		amazonSNS, _ := pushSettings["amazon_sns"].([]interface{})
+		for _, snsSettings := range amazonSNS {
+			if snsSettings, ok := snsSettings.(map[string]interface{}); ok {
+				snsSettings["aws_secret_access_key"] = ""
+			}
+		}
+	}
+
+	result := multierror.Append(
+		data.Set("duo", duo),
+		data.Set("phone", phone),
+		data.Set("push", push),
+	)
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,56 @@
+package guardian
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearFactorSecrets(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, dataSourceSchema(), nil)
+
+	require.NoError(t, data.Set("duo", []interface{}{
+		map[string]interface{}{
+			"enabled":         true,
+			"hostname":        "api-hostname",
+			"integration_key": "someKey",
+			"secret_key":      "someSecret",
+		},
+	}))
+	require.NoError(t, data.Set("phone", []interface{}{
+		map[string]interface{}{
+			"enabled":  true,
+			"provider": "twilio",
+			"options": []interface{}{
+				map[string]interface{}{
+					"sid":        "someSID",
+					"auth_token": "someToken",
+				},
+			},
+		},
+	}))
+
+	require.NoError(t, data.Set("push", []interface{}{
+		map[string]interface{}{
+			"enabled":  true,
+			"provider": "sns",
+			"amazon_sns": []interface{}{
+				map[string]interface{}{
+					"aws_access_key_id":     "someKeyID",
+					"aws_secret_access_key": "someSecretKey",
+				},
+			},
+		},
+	}))
+
+	require.NoError(t, clearFactorSecrets(data))
+
+	assert.Equal(t, "", data.Get("duo.0.secret_key"))
+	assert.Equal(t, "someKey", data.Get("duo.0.integration_key"))
+	assert.Equal(t, "", data.Get("phone.0.options.0.auth_token"))
+	assert.Equal(t, "someSID", data.Get("phone.0.options.0.sid"))
+	assert.Equal(t, "", data.Get("push.0.amazon_sns.0.aws_secret_access_key"))
+	assert.Equal(t, "someKeyID", data.Get("push.0.amazon_sns.0.aws_access_key_id"))
+}
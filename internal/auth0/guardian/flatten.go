@@ -19,7 +19,7 @@ func flattenMultiFactorPolicy(api *management.Management) (string, error) {
 	return flattenedPolicy, nil
 }
 
-func flattenPhone(enabled bool, api *management.Management) ([]interface{}, error) {
+func flattenPhone(d *schema.ResourceData, enabled bool, api *management.Management) ([]interface{}, error) {
 	phoneData := make(map[string]interface{})
 	phoneData["enabled"] = enabled
 
@@ -42,7 +42,7 @@ func flattenPhone(enabled bool, api *management.Management) ([]interface{}, erro
 	var phoneProviderOptions []interface{}
 	switch phoneProvider.GetProvider() {
 	case "twilio":
-		phoneProviderOptions, err = flattenTwilioOptions(api)
+		phoneProviderOptions, err = flattenTwilioOptions(d, api)
 		if err != nil {
 			return nil, err
 		}
@@ -71,7 +71,7 @@ func flattenAuth0Options(api *management.Management) ([]interface{}, error) {
 	return []interface{}{m}, nil
 }
 
-func flattenTwilioOptions(api *management.Management) ([]interface{}, error) {
+func flattenTwilioOptions(d *schema.ResourceData, api *management.Management) ([]interface{}, error) {
 	m := make(map[string]interface{})
 
 	template, err := api.Guardian.MultiFactor.SMS.Template()
@@ -87,7 +87,7 @@ func flattenTwilioOptions(api *management.Management) ([]interface{}, error) {
 		return nil, err
 	}
 
-	m["auth_token"] = twilio.GetAuthToken()
+	m["auth_token"] = flattenSecret(d, "phone.0.options.0.auth_token", twilio.GetAuthToken())
 	m["from"] = twilio.GetFrom()
 	m["messaging_service_sid"] = twilio.GetMessagingServiceSid()
 	m["sid"] = twilio.GetSID()
@@ -134,7 +134,7 @@ func flattenWebAuthnPlatform(enabled bool, api *management.Management) ([]interf
 	return []interface{}{webAuthnPlatformData}, nil
 }
 
-func flattenDUO(enabled bool, api *management.Management) ([]interface{}, error) {
+func flattenDUO(d *schema.ResourceData, enabled bool, api *management.Management) ([]interface{}, error) {
 	duoData := make(map[string]interface{})
 	duoData["enabled"] = enabled
 
@@ -148,7 +148,7 @@ func flattenDUO(enabled bool, api *management.Management) ([]interface{}, error)
 	}
 
 	duoData["integration_key"] = duoSettings.GetIntegrationKey()
-	duoData["secret_key"] = duoSettings.GetSecretKey()
+	duoData["secret_key"] = flattenSecret(d, "duo.0.secret_key", duoSettings.GetSecretKey())
 	duoData["hostname"] = duoSettings.GetHostname()
 
 	return []interface{}{duoData}, nil
@@ -191,7 +191,7 @@ func flattenPush(d *schema.ResourceData, enabled bool, api *management.Managemen
 			map[string]interface{}{
 				"aws_access_key_id":                 amazonSNS.GetAccessKeyID(),
 				"aws_region":                        amazonSNS.GetRegion(),
-				"aws_secret_access_key":             d.Get("push.0.amazon_sns.0.aws_secret_access_key"), // Does not get read back.
+				"aws_secret_access_key":             flattenSecret(d, "push.0.amazon_sns.0.aws_secret_access_key", amazonSNS.GetSecretAccessKeyID()),
 				"sns_apns_platform_application_arn": amazonSNS.GetAPNSPlatformApplicationARN(),
 				"sns_gcm_platform_application_arn":  amazonSNS.GetGCMPlatformApplicationARN(),
 			},
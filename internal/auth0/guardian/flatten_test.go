@@ -0,0 +1,300 @@
+package guardian
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenWebAuthnRelyingParty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"userVerification":       "required",
+			"overrideRelyingParty":   true,
+			"relyingPartyIdentifier": "example.com",
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	t.Run("webauthn_roaming", func(t *testing.T) {
+		webAuthnRoaming, err := flattenWebAuthnRoaming(true, api)
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"enabled":                  true,
+				"user_verification":        "required",
+				"override_relying_party":   true,
+				"relying_party_identifier": "example.com",
+			},
+		}, webAuthnRoaming)
+	})
+
+	t.Run("webauthn_platform", func(t *testing.T) {
+		webAuthnPlatform, err := flattenWebAuthnPlatform(true, api)
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"enabled":                  true,
+				"override_relying_party":   true,
+				"relying_party_identifier": "example.com",
+			},
+		}, webAuthnPlatform)
+	})
+}
+
+func TestFlattenDUOSecretKey(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenAPISecretKey string
+		expectedSecretKey string
+	}{
+		{
+			name:              "the secret key is returned by the API",
+			givenAPISecretKey: "someOtherSecret",
+			expectedSecretKey: "someOtherSecret",
+		},
+		{
+			name:              "the secret key is masked by the API",
+			givenAPISecretKey: "********",
+			expectedSecretKey: "someSecret",
+		},
+		{
+			name:              "the secret key is not returned by the API",
+			givenAPISecretKey: "",
+			expectedSecretKey: "someSecret",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/guardian/factors/duo/settings", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				err := json.NewEncoder(w).Encode(map[string]interface{}{
+					"host": "api-hostname",
+					"ikey": "someKey",
+					"skey": testCase.givenAPISecretKey,
+				})
+				require.NoError(t, err)
+			}))
+			t.Cleanup(server.Close)
+
+			api, err := management.New(server.URL, management.WithInsecure())
+			require.NoError(t, err)
+
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, map[string]interface{}{
+				"duo": []interface{}{
+					map[string]interface{}{
+						"enabled":         true,
+						"integration_key": "someKey",
+						"secret_key":      "someSecret",
+						"hostname":        "api-hostname",
+					},
+				},
+			})
+
+			duo, err := flattenDUO(data, true, api)
+			require.NoError(t, err)
+
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{
+					"enabled":         true,
+					"integration_key": "someKey",
+					"secret_key":      testCase.expectedSecretKey,
+					"hostname":        "api-hostname",
+				},
+			}, duo)
+		})
+	}
+}
+
+func TestFlattenTwilioAuthToken(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenAPIAuthToken string
+		expectedAuthToken string
+	}{
+		{
+			name:              "the auth token is returned by the API",
+			givenAPIAuthToken: "someOtherToken",
+			expectedAuthToken: "someOtherToken",
+		},
+		{
+			name:              "the auth token is masked by the API",
+			givenAPIAuthToken: "********",
+			expectedAuthToken: "someToken",
+		},
+		{
+			name:              "the auth token is not returned by the API",
+			givenAPIAuthToken: "",
+			expectedAuthToken: "someToken",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				var response map[string]interface{}
+				switch r.URL.Path {
+				case "/api/v2/guardian/factors/sms/templates":
+					response = map[string]interface{}{
+						"enrollment_message":   "enroll",
+						"verification_message": "verify",
+					}
+				case "/api/v2/guardian/factors/sms/providers/twilio":
+					response = map[string]interface{}{
+						"from":       "+15555555555",
+						"sid":        "someSID",
+						"auth_token": testCase.givenAPIAuthToken,
+					}
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+				}
+
+				err := json.NewEncoder(w).Encode(response)
+				require.NoError(t, err)
+			}))
+			t.Cleanup(server.Close)
+
+			api, err := management.New(server.URL, management.WithInsecure())
+			require.NoError(t, err)
+
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, map[string]interface{}{
+				"phone": []interface{}{
+					map[string]interface{}{
+						"enabled":       true,
+						"provider":      "twilio",
+						"message_types": []interface{}{"sms"},
+						"options": []interface{}{
+							map[string]interface{}{
+								"from":       "+15555555555",
+								"sid":        "someSID",
+								"auth_token": "someToken",
+							},
+						},
+					},
+				},
+			})
+
+			options, err := flattenTwilioOptions(data, api)
+			require.NoError(t, err)
+
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{
+					"enrollment_message":    "enroll",
+					"verification_message":  "verify",
+					"auth_token":            testCase.expectedAuthToken,
+					"from":                  "+15555555555",
+					"messaging_service_sid": "",
+					"sid":                   "someSID",
+				},
+			}, options)
+		})
+	}
+}
+
+func TestFlattenAmazonSNSSecretAccessKey(t *testing.T) {
+	var testCases = []struct {
+		name                    string
+		givenAPISecretAccessKey string
+		expectedSecretAccessKey string
+	}{
+		{
+			name:                    "the secret access key is returned by the API",
+			givenAPISecretAccessKey: "someOtherSecret",
+			expectedSecretAccessKey: "someOtherSecret",
+		},
+		{
+			name:                    "the secret access key is masked by the API",
+			givenAPISecretAccessKey: "********",
+			expectedSecretAccessKey: "someSecret",
+		},
+		{
+			name:                    "the secret access key is not returned by the API",
+			givenAPISecretAccessKey: "",
+			expectedSecretAccessKey: "someSecret",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				var response map[string]interface{}
+				switch r.URL.Path {
+				case "/api/v2/guardian/factors/push-notification/selected-provider":
+					response = map[string]interface{}{
+						"provider": "sns",
+					}
+				case "/api/v2/prompts/mfa-push":
+					response = map[string]interface{}{
+						"app_name": "CustomApp",
+					}
+				case "/api/v2/guardian/factors/push-notification/providers/sns":
+					response = map[string]interface{}{
+						"aws_access_key_id":     "someKeyID",
+						"aws_region":            "us-west-1",
+						"aws_secret_access_key": testCase.givenAPISecretAccessKey,
+					}
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+				}
+
+				err := json.NewEncoder(w).Encode(response)
+				require.NoError(t, err)
+			}))
+			t.Cleanup(server.Close)
+
+			api, err := management.New(server.URL, management.WithInsecure())
+			require.NoError(t, err)
+
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, map[string]interface{}{
+				"push": []interface{}{
+					map[string]interface{}{
+						"enabled":  true,
+						"provider": "sns",
+						"amazon_sns": []interface{}{
+							map[string]interface{}{
+								"aws_access_key_id":     "someKeyID",
+								"aws_region":            "us-west-1",
+								"aws_secret_access_key": "someSecret",
+							},
+						},
+					},
+				},
+			})
+
+			push, err := flattenPush(data, true, api)
+			require.NoError(t, err)
+			require.Len(t, push, 1)
+
+			amazonSNS := push[0].(map[string]interface{})["amazon_sns"].([]interface{})
+			require.Len(t, amazonSNS, 1)
+
+			assert.Equal(
+				t,
+				testCase.expectedSecretAccessKey,
+				amazonSNS[0].(map[string]interface{})["aws_secret_access_key"],
+			)
+		})
+	}
+}
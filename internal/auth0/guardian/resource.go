@@ -2,10 +2,12 @@ package guardian
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -13,6 +15,8 @@ import (
 	internalValidation "github.com/auth0/terraform-provider-auth0/internal/validation"
 )
 
+var errEmptyGuardianID = fmt.Errorf("ID cannot be empty")
+
 // NewResource will return a new auth0_guardian resource.
 func NewResource() *schema.Resource {
 	return &schema.Resource{
@@ -20,8 +24,12 @@ func NewResource() *schema.Resource {
 		ReadContext:   readGuardian,
 		UpdateContext: updateGuardian,
 		DeleteContext: deleteGuardian,
+		CustomizeDiff: customdiff.All(
+			validateWebAuthnRelyingParty,
+			validatePhoneMessageTypes,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGuardian,
 		},
 		Description: "Multi-Factor Authentication works by requiring additional factors during the login process " +
 			"to prevent unauthorized access. With this resource you can configure some options available for MFA.",
@@ -66,7 +74,8 @@ func NewResource() *schema.Resource {
 								},
 								false,
 							),
-							Description: "User verification, one of `discouraged`, `preferred` or `required`.",
+							Description: "User verification, one of `discouraged`, `preferred` or `required`. " +
+								"If omitted, the value defaulted by Auth0 is stored in the state.",
 						},
 						"override_relying_party": {
 							Type:     schema.TypeBool,
@@ -80,7 +89,8 @@ func NewResource() *schema.Resource {
 							Optional:     true,
 							Computed:     true,
 							RequiredWith: []string{"webauthn_roaming.0.override_relying_party"},
-							Description:  "The Relying Party should be a suffix of the custom domain.",
+							Description: "The Relying Party should be a suffix of the custom domain. " +
+								"Can only be set when `override_relying_party` is set to `true`.",
 						},
 					},
 				},
@@ -112,7 +122,8 @@ func NewResource() *schema.Resource {
 							Optional:     true,
 							Computed:     true,
 							RequiredWith: []string{"webauthn_platform.0.override_relying_party"},
-							Description:  "The Relying Party should be a suffix of the custom domain.",
+							Description: "The Relying Party should be a suffix of the custom domain. " +
+								"Can only be set when `override_relying_party` is set to `true`.",
 						},
 					},
 				},
@@ -152,11 +163,13 @@ func NewResource() *schema.Resource {
 							Type:     schema.TypeList,
 							Optional: true,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(phoneMessageTypes, false),
 							},
 							RequiredWith: []string{"phone.0.provider"},
 							Description: "Message types to use, array of `sms` and/or `voice`. " +
-								"Adding both to the array should enable the user to choose.",
+								"Adding both to the array should enable the user to choose. " +
+								"The `phone-message-hook` provider only supports `sms`.",
 						},
 						"options": {
 							Type:        schema.TypeList,
@@ -384,7 +397,7 @@ func readGuardian(ctx context.Context, d *schema.ResourceData, m interface{}) di
 		case "recovery-code":
 			result = multierror.Append(result, d.Set("recovery_code", factor.GetEnabled()))
 		case "sms":
-			phone, err := flattenPhone(factor.GetEnabled(), api)
+			phone, err := flattenPhone(d, factor.GetEnabled(), api)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -405,7 +418,7 @@ func readGuardian(ctx context.Context, d *schema.ResourceData, m interface{}) di
 
 			result = multierror.Append(result, d.Set("webauthn_platform", webAuthnPlatform))
 		case "duo":
-			duo, err := flattenDUO(factor.GetEnabled(), api)
+			duo, err := flattenDUO(d, factor.GetEnabled(), api)
 			if err != nil {
 				return diag.FromErr(err)
 			}
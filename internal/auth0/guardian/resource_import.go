@@ -0,0 +1,26 @@
+package guardian
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importGuardian imports the guardian configuration, whose policy and
+// factors are then populated by the read. As the guardian configuration
+// is a tenant singleton that has no ID within the Management API, any
+// non-empty string can be used as the import ID.
+//
+// Secrets that can't be read back from the API, like the Amazon SNS secret
+// access key, are left empty and are set again on the next apply.
+func importGuardian(
+	ctx context.Context,
+	data *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	if data.Id() == "" {
+		return nil, errEmptyGuardianID
+	}
+
+	return schema.ImportStatePassthroughContext(ctx, data, meta)
+}
@@ -0,0 +1,48 @@
+package guardian
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGuardian(t *testing.T) {
+	var testCases = []struct {
+		testName      string
+		givenID       string
+		expectedError error
+	}{
+		{
+			testName: "it keeps the given ID",
+			givenID:  "24940d4b-4bd4-44e7-894e-f92e4de36a40",
+		},
+		{
+			testName:      "it fails when the given ID is empty",
+			givenID:       "",
+			expectedError: errEmptyGuardianID,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, NewResource().Schema, nil)
+			data.SetId(testCase.givenID)
+
+			// No API is given, as the factors are only read after the import.
+			actualData, err := importGuardian(context.Background(), data, nil)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				assert.Nil(t, actualData)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, actualData, 1)
+			assert.Equal(t, testCase.givenID, actualData[0].Id())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package guardian_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -341,6 +342,118 @@ func TestAccGuardianWebAuthnPlatform(t *testing.T) {
 	})
 }
 
+const testAccConfigureWebAuthnImport = `
+resource "auth0_guardian" "foo" {
+	policy = "all-applications"
+	webauthn_roaming {
+		enabled = true
+	}
+	webauthn_platform {
+		enabled = true
+	}
+}
+`
+
+func TestAccGuardianWebAuthnImport(t *testing.T) {
+	acctest.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigureWebAuthnImport,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "webauthn_roaming.0.enabled", "true"),
+					resource.TestCheckResourceAttrSet("auth0_guardian.foo", "webauthn_roaming.0.user_verification"),
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "webauthn_platform.0.enabled", "true"),
+				),
+			},
+			{
+				ResourceName:       "auth0_guardian.foo",
+				ImportState:        true,
+				ImportStateVerify:  true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:   testAccConfigureWebAuthnImport,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccGuardianWebAuthnRelyingPartyValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_guardian" "foo" {
+	policy = "all-applications"
+	webauthn_roaming {
+		enabled                  = true
+		override_relying_party   = false
+		relying_party_identifier = "example.com"
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					"webauthn_roaming.0.relying_party_identifier: can only be set when " +
+						"`webauthn_roaming.0.override_relying_party` is set to true",
+				),
+			},
+			{
+				Config: `
+resource "auth0_guardian" "foo" {
+	policy = "all-applications"
+	webauthn_platform {
+		enabled                  = true
+		override_relying_party   = false
+		relying_party_identifier = "example.com"
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					"webauthn_platform.0.relying_party_identifier: can only be set when " +
+						"`webauthn_platform.0.override_relying_party` is set to true",
+				),
+			},
+		},
+	})
+}
+
+func TestAccGuardianPhoneMessageTypesValidation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: acctest.TestFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "auth0_guardian" "foo" {
+	policy = "all-applications"
+	phone {
+		enabled       = true
+		provider      = "twilio"
+		message_types = ["sms", "email"]
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					`expected phone.0.message_types.1 to be one of \[sms voice\], got email`,
+				),
+			},
+			{
+				Config: `
+resource "auth0_guardian" "foo" {
+	policy = "all-applications"
+	phone {
+		enabled       = true
+		provider      = "phone-message-hook"
+		message_types = ["sms", "voice"]
+	}
+}`,
+				ExpectError: regexp.MustCompile(
+					`phone.0.message_types: "voice" is not supported by the "phone-message-hook" provider, ` +
+						`supported message types are: sms`,
+				),
+			},
+		},
+	})
+}
+
 const testAccConfigureDUOCreate = `
 resource "auth0_guardian" "foo" {
 	policy = "all-applications"
@@ -509,3 +622,80 @@ func TestAccGuardianPush(t *testing.T) {
 		},
 	})
 }
+
+const testAccConfigureGuardianImport = `
+resource "auth0_guardian" "foo" {
+	policy        = "all-applications"
+	email         = true
+	otp           = true
+	recovery_code = true
+
+	phone {
+		enabled       = true
+		provider      = "twilio"
+		message_types = ["sms"]
+
+		options {
+			enrollment_message    = "enroll foo"
+			verification_message  = "verify foo"
+			from                  = "from bar"
+			messaging_service_sid = "foo"
+			auth_token            = "bar"
+			sid                   = "foo"
+		}
+	}
+
+	webauthn_roaming {
+		enabled           = true
+		user_verification = "required"
+	}
+
+	webauthn_platform {
+		enabled = true
+	}
+
+	duo {
+		enabled         = true
+		integration_key = "someKey"
+		secret_key      = "someSecret"
+		hostname        = "api-hostname"
+	}
+
+	push {
+		enabled  = true
+		provider = "guardian"
+
+		custom_app {
+			app_name        = "CustomApp"
+			apple_app_link  = "https://itunes.apple.com/us/app/my-app/id123121"
+			google_app_link = "https://play.google.com/store/apps/details?id=com.my.app"
+		}
+	}
+}
+`
+
+func TestAccGuardianImport(t *testing.T) {
+	acctest.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigureGuardianImport,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "email", "true"),
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "phone.0.provider", "twilio"),
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "duo.0.enabled", "true"),
+					resource.TestCheckResourceAttr("auth0_guardian.foo", "push.0.custom_app.0.app_name", "CustomApp"),
+				),
+			},
+			{
+				ResourceName:       "auth0_guardian.foo",
+				ImportState:        true,
+				ImportStateVerify:  true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:   testAccConfigureGuardianImport,
+				PlanOnly: true,
+			},
+		},
+	})
+}
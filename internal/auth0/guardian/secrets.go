@@ -0,0 +1,23 @@
+package guardian
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// isUnreadableSecret returns true if the secret returned by
+// the API is either empty or masked, e.g. "********".
+func isUnreadableSecret(secret string) bool {
+	return strings.Trim(secret, "*") == ""
+}
+
+// flattenSecret returns the secret returned by the API, or the one
+// already in the state at key if the API doesn't expose its value.
+func flattenSecret(d *schema.ResourceData, key string, secret string) string {
+	if isUnreadableSecret(secret) {
+		return d.Get(key).(string)
+	}
+
+	return secret
+}
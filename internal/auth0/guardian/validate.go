@@ -0,0 +1,129 @@
+package guardian
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateWebAuthnRelyingParty ensures that a custom relying party identifier
+// is only configured when the relying party is also being overridden, as the
+// identifier is otherwise ignored by the API.
+func validateWebAuthnRelyingParty(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+
+	var result *multierror.Error
+	for _, factor := range []string{"webauthn_roaming", "webauthn_platform"} {
+		result = multierror.Append(result, checkWebAuthnRelyingParty(factor, config.GetAttr(factor)))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func checkWebAuthnRelyingParty(factor string, rawFactor cty.Value) error {
+	if !rawFactor.IsKnown() || rawFactor.IsNull() {
+		return nil
+	}
+
+	var err error
+	rawFactor.ForEachElement(func(_ cty.Value, config cty.Value) (stop bool) {
+		identifier := config.GetAttr("relying_party_identifier")
+		if identifier.IsKnown() && (identifier.IsNull() || identifier.AsString() == "") {
+			return stop
+		}
+
+		overrideRelyingParty := config.GetAttr("override_relying_party")
+		if !overrideRelyingParty.IsKnown() || (!overrideRelyingParty.IsNull() && overrideRelyingParty.True()) {
+			return stop
+		}
+
+		err = fmt.Errorf(
+			"%s.0.relying_party_identifier: can only be set when `%s.0.override_relying_party` is set to true",
+			factor,
+			factor,
+		)
+
+		return stop
+	})
+
+	return err
+}
+
+// phoneMessageTypes holds the message types available to the phone factor.
+var phoneMessageTypes = []string{"sms", "voice"}
+
+// phoneProviderUnsupportedMessageTypes holds the message
+// types that the API rejects for a given phone provider.
+var phoneProviderUnsupportedMessageTypes = map[string][]string{
+	"phone-message-hook": {"voice"},
+}
+
+// validatePhoneMessageTypes rejects at plan time the combinations of phone
+// provider and message types that the API does not accept.
+func validatePhoneMessageTypes(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	return checkPhoneMessageTypes(diff.GetRawConfig().GetAttr("phone"))
+}
+
+func checkPhoneMessageTypes(rawPhone cty.Value) error {
+	if !rawPhone.IsKnown() || rawPhone.IsNull() {
+		return nil
+	}
+
+	var result *multierror.Error
+	rawPhone.ForEachElement(func(_ cty.Value, config cty.Value) (stop bool) {
+		provider := config.GetAttr("provider")
+		messageTypes := config.GetAttr("message_types")
+		if !provider.IsKnown() || provider.IsNull() || !messageTypes.IsWhollyKnown() || messageTypes.IsNull() {
+			return stop
+		}
+
+		unsupportedMessageTypes := phoneProviderUnsupportedMessageTypes[provider.AsString()]
+
+		messageTypes.ForEachElement(func(_ cty.Value, messageType cty.Value) (stop bool) {
+			if messageType.IsNull() {
+				return stop
+			}
+
+			for _, unsupportedMessageType := range unsupportedMessageTypes {
+				if messageType.AsString() != unsupportedMessageType {
+					continue
+				}
+
+				result = multierror.Append(result, fmt.Errorf(
+					"phone.0.message_types: %q is not supported by the %q provider, supported message types are: %s",
+					unsupportedMessageType,
+					provider.AsString(),
+					strings.Join(supportedPhoneMessageTypes(provider.AsString()), ", "),
+				))
+			}
+
+			return stop
+		})
+
+		return stop
+	})
+
+	return result.ErrorOrNil()
+}
+
+func supportedPhoneMessageTypes(provider string) []string {
+	var supportedMessageTypes []string
+	for _, messageType := range phoneMessageTypes {
+		supported := true
+		for _, unsupportedMessageType := range phoneProviderUnsupportedMessageTypes[provider] {
+			if messageType == unsupportedMessageType {
+				supported = false
+			}
+		}
+
+		if supported {
+			supportedMessageTypes = append(supportedMessageTypes, messageType)
+		}
+	}
+
+	return supportedMessageTypes
+}
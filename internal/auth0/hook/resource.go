@@ -2,7 +2,6 @@ package hook
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -10,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -99,11 +99,9 @@ func readHook(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 	api := m.(*management.Management)
 	hook, err := api.Hook.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -142,11 +140,9 @@ func updateHook(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 func deleteHook(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 	if err := api.Hook.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
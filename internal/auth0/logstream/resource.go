@@ -2,7 +2,6 @@ package logstream
 
 import (
 	"context"
-	"net/http"
 	"strings"
 
 	"github.com/auth0/go-auth0/management"
@@ -10,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
 var validLogStreamTypes = []string{
@@ -288,7 +289,7 @@ func readLogStream(ctx context.Context, d *schema.ResourceData, m interface{}) d
 
 	logStream, err := api.LogStream.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -321,7 +322,7 @@ func deleteLogStream(ctx context.Context, d *schema.ResourceData, m interface{})
 	api := m.(*management.Management)
 
 	if err := api.LogStream.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -2,13 +2,13 @@ package organization
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	internalSchema "github.com/auth0/terraform-provider-auth0/internal/schema"
 )
 
@@ -69,7 +69,7 @@ func readOrganizationForDataSource(ctx context.Context, data *schema.ResourceDat
 	if organizationID != "" {
 		foundOrganization, err = api.Organization.Read(organizationID)
 		if err != nil {
-			if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+			if internalError.IsStatusNotFound(err) {
 				data.SetId("")
 				return nil
 			}
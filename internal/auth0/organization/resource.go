@@ -2,7 +2,6 @@ package organization
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-cty/cty"
@@ -10,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -91,7 +91,7 @@ func readOrganization(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	organization, err := api.Organization.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok && mErr.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -123,7 +123,7 @@ func deleteOrganization(ctx context.Context, d *schema.ResourceData, m interface
 	api := m.(*management.Management)
 
 	if err := api.Organization.Delete(d.Id()); err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
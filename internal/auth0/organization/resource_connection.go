@@ -3,7 +3,6 @@ package organization
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -11,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/mutex"
 )
 
@@ -96,7 +96,7 @@ func readOrganizationConnection(ctx context.Context, data *schema.ResourceData,
 
 	organizationConnection, err := api.Organization.Connection(organizationID, connectionID)
 	if err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			data.SetId("")
 			return nil
 		}
@@ -145,7 +145,7 @@ func deleteOrganizationConnection(ctx context.Context, data *schema.ResourceData
 	connectionID := data.Get("connection_id").(string)
 
 	if err := api.Organization.DeleteConnection(organizationID, connectionID); err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			data.SetId("")
 			return nil
 		}
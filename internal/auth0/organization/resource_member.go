@@ -3,13 +3,13 @@ package organization
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/mutex"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
@@ -103,7 +103,7 @@ func removeMemberRoles(orgID string, userID string, roles []interface{}, api *ma
 	err := api.Organization.DeleteMemberRoles(orgID, userID, rolesToRemove)
 	if err != nil {
 		// Ignore 404 errors as the role may have been deleted prior to un-assigning them from the member.
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			return nil
 		}
 		return err
@@ -133,7 +133,7 @@ func readOrganizationMember(ctx context.Context, d *schema.ResourceData, m inter
 
 	roles, err := api.Organization.MemberRoles(orgID, userID)
 	if err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -175,7 +175,7 @@ func deleteOrganizationMember(ctx context.Context, d *schema.ResourceData, m int
 	defer mutex.Global.Unlock(orgID)
 
 	if err := api.Organization.DeleteMember(orgID, []string{userID}); err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -2,12 +2,13 @@ package rule
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 )
 
 // NewConfigResource will return a new auth0_rule_config resource.
@@ -59,11 +60,9 @@ func readRuleConfig(ctx context.Context, d *schema.ResourceData, m interface{})
 	api := m.(*management.Management)
 	ruleConfig, err := api.RuleConfig.Read(d.Id())
 	if err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -85,11 +84,9 @@ func updateRuleConfig(ctx context.Context, d *schema.ResourceData, m interface{}
 func deleteRuleConfig(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 	if err := api.RuleConfig.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 	}
 
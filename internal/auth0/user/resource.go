@@ -3,7 +3,6 @@ package user
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
@@ -13,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
 	"github.com/auth0/terraform-provider-auth0/internal/value"
 )
 
@@ -162,7 +162,7 @@ func readUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 
 	user, err := api.User.Read(d.Id())
 	if err != nil {
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -260,11 +260,9 @@ func updateUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 func deleteUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api := m.(*management.Management)
 	if err := api.User.Delete(d.Id()); err != nil {
-		if mErr, ok := err.(management.Error); ok {
-			if mErr.Status() == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if internalError.IsStatusNotFound(err) {
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -449,7 +447,7 @@ func removeUserRoles(api *management.Management, userID string, userRolesToRemov
 	err := api.User.RemoveRoles(userID, rmRoles)
 	if err != nil {
 		// Ignore 404 errors as the role may have been deleted prior to un-assigning them from the user.
-		if err, ok := err.(management.Error); ok && err.Status() == http.StatusNotFound {
+		if internalError.IsStatusNotFound(err) {
 			return nil
 		}
 	}
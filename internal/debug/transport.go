@@ -0,0 +1,218 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are the headers whose values are never logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveFields are the request and response body fields whose
+// values are never logged, no matter how deeply they are nested.
+// They match the JSON field names used by the go-auth0 SDK, which
+// differ from the attribute names of the Terraform schema.
+var sensitiveFields = map[string]bool{
+	"access_token":                   true,
+	"access_token_secret":            true,
+	"accessKeyId":                    true,
+	"api_key":                        true,
+	"app_secret":                     true,
+	"auth_token":                     true,
+	"aws_secret_access_key":          true,
+	"client_secret":                  true,
+	"cname_api_key":                  true,
+	"configuration":                  true,
+	"datadogApiKey":                  true,
+	"httpAuthorization":              true,
+	"key":                            true,
+	"mixpanelServiceAccountPassword": true,
+	"password":                       true,
+	"secret":                         true,
+	"secretAccessKey":                true,
+	"secrets":                        true,
+	"segmentWriteKey":                true,
+	"signing_secret":                 true,
+	"skey":                           true,
+	"smtp_pass":                      true,
+	"splunkToken":                    true,
+	"twilio_token":                   true,
+	"value":                          true,
+}
+
+// Transport logs every request sent to the Auth0 Management API and the
+// response received at the DEBUG level, redacting credentials
+// and sensitive fields before they reach the logs.
+type Transport struct {
+	base   http.RoundTripper
+	logger func(format string, v ...interface{})
+}
+
+// NewTransport wraps the base transport with request and response logging.
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		base:   base,
+		logger: log.Printf,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, logging both its request and response.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger(
+		"[DEBUG] Auth0 API Request: %s %s\n%s\n%s",
+		request.Method,
+		request.URL.String(),
+		formatHeader(request.Header),
+		redactBody(request.Header.Get("Content-Type"), requestBody),
+	)
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		t.logger("[DEBUG] Auth0 API Request: %s %s failed: %s", request.Method, request.URL.String(), err)
+		return response, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.logger(
+		"[DEBUG] Auth0 API Response: %s %s: %s\n%s\n%s",
+		request.Method,
+		request.URL.String(),
+		response.Status,
+		formatHeader(response.Header),
+		redactBody(response.Header.Get("Content-Type"), responseBody),
+	)
+
+	return response, nil
+}
+
+// readRequestBody returns a copy of the request body
+// without consuming the one that is about to be sent.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func formatHeader(header http.Header) string {
+	header = header.Clone()
+	for _, name := range sensitiveHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, name+": "+strings.Join(header.Values(name), ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// redactBody returns the body with the values of sensitive fields
+// redacted. Bodies that can't be parsed are not logged at all, as
+// there is no telling whether they hold any sensitive value.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return redacted
+		}
+
+		for name := range values {
+			if sensitiveFields[name] {
+				values.Set(name, redacted)
+			}
+		}
+
+		return values.Encode()
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return redacted
+	}
+
+	redactedBody, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return redacted
+	}
+
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, fieldValue := range value {
+			if sensitiveFields[name] {
+				value[name] = redacted
+				continue
+			}
+
+			value[name] = redactValue(fieldValue)
+		}
+	case []interface{}:
+		for index, element := range value {
+			value[index] = redactValue(element)
+		}
+	}
+
+	return value
+}
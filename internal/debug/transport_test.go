@@ -0,0 +1,234 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportRedactsSensitiveValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"sms","options":{"twilio_token":"twilio-secret","from":"+15555555555"}}`, string(body))
+		assert.Equal(t, "Bearer my-api-token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"client_id":"my-client","client_secret":"my-client-secret"}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	var logs []string
+	transport := NewTransport(http.DefaultTransport)
+	transport.logger = func(format string, v ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	}
+
+	request, err := http.NewRequest(
+		http.MethodPost,
+		server.URL+"/api/v2/connections",
+		strings.NewReader(`{"name":"sms","options":{"twilio_token":"twilio-secret","from":"+15555555555"}}`),
+	)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer my-api-token")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := transport.RoundTrip(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+
+	responseBody, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"client_id":"my-client","client_secret":"my-client-secret"}`, string(responseBody))
+
+	require.Len(t, logs, 2)
+	for _, message := range logs {
+		assert.True(t, strings.HasPrefix(message, "[DEBUG] "))
+		assert.NotContains(t, message, "my-api-token")
+		assert.NotContains(t, message, "twilio-secret")
+		assert.NotContains(t, message, "my-client-secret")
+	}
+	assert.Contains(t, logs[0], "POST "+server.URL+"/api/v2/connections")
+	assert.Contains(t, logs[0], "Authorization: [REDACTED]")
+	assert.Contains(t, logs[0], `"from":"+15555555555"`)
+	assert.Contains(t, logs[0], `"twilio_token":"[REDACTED]"`)
+	assert.Contains(t, logs[1], "200 OK")
+	assert.Contains(t, logs[1], `"client_id":"my-client"`)
+	assert.Contains(t, logs[1], `"client_secret":"[REDACTED]"`)
+}
+
+func TestRedactBody(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "empty body",
+			contentType: "application/json",
+			body:        "",
+			expected:    "",
+		},
+		{
+			name:        "nested sensitive fields",
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"options":{"signing_key":{"cert":"my-cert","key":"my-key"}}}]`,
+			expected:    `[{"options":{"signing_key":{"cert":"my-cert","key":"[REDACTED]"}}}]`,
+		},
+		{
+			name:        "form encoded body",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "client_id=my-client&client_secret=my-client-secret&grant_type=client_credentials",
+			expected:    "client_id=my-client&client_secret=%5BREDACTED%5D&grant_type=client_credentials",
+		},
+		{
+			name:        "unparsable body",
+			contentType: "text/plain",
+			body:        "client_secret=my-client-secret",
+			expected:    "[REDACTED]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := redactBody(testCase.contentType, []byte(testCase.body))
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRedactBodyRedactsSDKSecrets(t *testing.T) {
+	var testCases = []struct {
+		name    string
+		payload interface{}
+		secrets []string
+	}{
+		{
+			name: "http log stream",
+			payload: &management.LogStream{
+				Type: auth0.String(management.LogStreamTypeHTTP),
+				Sink: &management.LogStreamSinkHTTP{
+					Endpoint:      auth0.String("https://example.com/logs"),
+					Authorization: auth0.String("http-authorization-secret"),
+				},
+			},
+			secrets: []string{"http-authorization-secret"},
+		},
+		{
+			name: "datadog log stream",
+			payload: &management.LogStream{
+				Type: auth0.String(management.LogStreamTypeDatadog),
+				Sink: &management.LogStreamSinkDatadog{
+					Region: auth0.String("us"),
+					APIKey: auth0.String("datadog-api-key-secret"),
+				},
+			},
+			secrets: []string{"datadog-api-key-secret"},
+		},
+		{
+			name: "splunk log stream",
+			payload: &management.LogStream{
+				Type: auth0.String(management.LogStreamTypeSplunk),
+				Sink: &management.LogStreamSinkSplunk{
+					Domain: auth0.String("splunk.example.com"),
+					Token:  auth0.String("splunk-token-secret"),
+				},
+			},
+			secrets: []string{"splunk-token-secret"},
+		},
+		{
+			name: "segment log stream",
+			payload: &management.LogStream{
+				Type: auth0.String(management.LogStreamTypeSegment),
+				Sink: &management.LogStreamSinkSegment{
+					WriteKey: auth0.String("segment-write-key-secret"),
+				},
+			},
+			secrets: []string{"segment-write-key-secret"},
+		},
+		{
+			name: "mixpanel log stream",
+			payload: &management.LogStream{
+				Type: auth0.String(management.LogStreamTypeMixpanel),
+				Sink: &management.LogStreamSinkMixpanel{
+					Region:                 auth0.String("us"),
+					ProjectID:              auth0.String("123456"),
+					ServiceAccountUsername: auth0.String("mixpanel-user"),
+					ServiceAccountPassword: auth0.String("mixpanel-password-secret"),
+				},
+			},
+			secrets: []string{"mixpanel-password-secret"},
+		},
+		{
+			name: "ses email provider",
+			payload: &management.EmailProvider{
+				Name: auth0.String(management.EmailProviderSES),
+				Credentials: &management.EmailProviderCredentialsSES{
+					AccessKeyID:     auth0.String("ses-access-key-id-secret"),
+					SecretAccessKey: auth0.String("ses-secret-access-key-secret"),
+					Region:          auth0.String("us-east-1"),
+				},
+			},
+			secrets: []string{"ses-access-key-id-secret", "ses-secret-access-key-secret"},
+		},
+		{
+			name: "duo settings",
+			payload: &management.MultiFactorDUOSettings{
+				Hostname:       auth0.String("api-hostname"),
+				IntegrationKey: auth0.String("duo-integration-key"),
+				SecretKey:      auth0.String("duo-secret-key-secret"),
+			},
+			secrets: []string{"duo-secret-key-secret"},
+		},
+		{
+			name: "connection configuration",
+			payload: &management.Connection{
+				Name:     auth0.String("my-database"),
+				Strategy: auth0.String(management.ConnectionStrategyAuth0),
+				Options: &management.ConnectionOptions{
+					Configuration: &map[string]string{
+						"db_password": "configuration-secret",
+					},
+				},
+			},
+			secrets: []string{"configuration-secret"},
+		},
+		{
+			name: "rule config",
+			payload: &management.RuleConfig{
+				Key:   auth0.String("my_key"),
+				Value: auth0.String("rule-config-value-secret"),
+			},
+			secrets: []string{"rule-config-value-secret"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			body, err := json.Marshal(testCase.payload)
+			require.NoError(t, err)
+
+			for _, secret := range testCase.secrets {
+				require.Contains(t, string(body), secret, "Expected the SDK to send the secret")
+			}
+
+			actual := redactBody("application/json", body)
+
+			for _, secret := range testCase.secrets {
+				assert.NotContains(t, actual, secret)
+			}
+			assert.Contains(t, actual, redacted)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package error
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+// IsStatusNotFound checks whether the error is a Management API error
+// with a 404 status code, even when it has been wrapped.
+func IsStatusNotFound(err error) bool {
+	var managementError management.Error
+	if !errors.As(err, &managementError) {
+		return false
+	}
+
+	return managementError.Status() == http.StatusNotFound
+}
+
+// IsStatusConflict checks whether the error is a Management API error
+// with a 409 status code, even when it has been wrapped.
+func IsStatusConflict(err error) bool {
+	var managementError management.Error
+	if !errors.As(err, &managementError) {
+		return false
+	}
+
+	return managementError.Status() == http.StatusConflict
+}
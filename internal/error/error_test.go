@@ -0,0 +1,130 @@
+package error
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStatusNotFound(t *testing.T) {
+	notFoundErr := newManagementError(t, http.StatusNotFound)
+	badRequestErr := newManagementError(t, http.StatusBadRequest)
+
+	var testCases = []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "non management error",
+			err:      fmt.Errorf("not found"),
+			expected: false,
+		},
+		{
+			name:     "management error with a 404 status",
+			err:      notFoundErr,
+			expected: true,
+		},
+		{
+			name:     "management error with a different status",
+			err:      badRequestErr,
+			expected: false,
+		},
+		{
+			name:     "wrapped management error with a 404 status",
+			err:      fmt.Errorf("failed to read resource: %w", notFoundErr),
+			expected: true,
+		},
+		{
+			name:     "wrapped management error with a different status",
+			err:      fmt.Errorf("failed to read resource: %w", badRequestErr),
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, IsStatusNotFound(testCase.err))
+		})
+	}
+}
+
+func TestIsStatusConflict(t *testing.T) {
+	conflictErr := newManagementError(t, http.StatusConflict)
+	notFoundErr := newManagementError(t, http.StatusNotFound)
+
+	var testCases = []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "non management error",
+			err:      fmt.Errorf("conflict"),
+			expected: false,
+		},
+		{
+			name:     "management error with a 409 status",
+			err:      conflictErr,
+			expected: true,
+		},
+		{
+			name:     "management error with a different status",
+			err:      notFoundErr,
+			expected: false,
+		},
+		{
+			name:     "wrapped management error with a 409 status",
+			err:      fmt.Errorf("failed to update resource: %w", conflictErr),
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, IsStatusConflict(testCase.err))
+		})
+	}
+}
+
+// newManagementError returns the error the Management
+// API client gives back for a response with the given status.
+func newManagementError(t *testing.T, status int) error {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"statusCode": status,
+			"error":      http.StatusText(status),
+			"message":    "Stubbed error",
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(server.URL, management.WithInsecure())
+	require.NoError(t, err)
+
+	_, err = api.Role.Read("rol_123")
+	require.Error(t, err)
+
+	return err
+}
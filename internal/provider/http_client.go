@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// defaultAPIClientTimeout disables the timeout, so that
+	// requests can wait for as long as rate limit retries take.
+	defaultAPIClientTimeout = 0
+
+	// defaultAPIClientMaxIdleConnections replaces the 2 idle connections per host
+	// of http.DefaultTransport, which forces concurrent applies to keep opening
+	// new connections to the Management API.
+	defaultAPIClientMaxIdleConnections = 100
+)
+
+// newHTTPTransport returns a transport keeping up to maxIdleConnections
+// idle connections open to the Management API for reuse.
+func newHTTPTransport(maxIdleConnections int) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConnections
+	transport.MaxIdleConnsPerHost = maxIdleConnections
+
+	return transport
+}
+
+// envDefaultIntFunc returns a schema.SchemaDefaultFunc reading an
+// integer from the given env var, or defaultValue when it's not set.
+func envDefaultIntFunc(key string, defaultValue int) schema.SchemaDefaultFunc {
+	return func() (interface{}, error) {
+		v := os.Getenv(key)
+		if v == "" {
+			return defaultValue, nil
+		}
+		return strconv.Atoi(v)
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPTransport(t *testing.T) {
+	transport := newHTTPTransport(50)
+
+	assert.Equal(t, 50, transport.MaxIdleConns)
+	assert.Equal(t, 50, transport.MaxIdleConnsPerHost)
+	assert.NotNil(t, transport.Proxy, "the defaults of http.DefaultTransport must be kept")
+}
+
+func TestEnvDefaultIntFunc(t *testing.T) {
+	t.Run("it defaults when the env var is not set", func(t *testing.T) {
+		t.Setenv("AUTH0_API_CLIENT_TIMEOUT", "")
+
+		value, err := envDefaultIntFunc("AUTH0_API_CLIENT_TIMEOUT", 30)()
+		require.NoError(t, err)
+		assert.Equal(t, 30, value)
+	})
+
+	t.Run("it reads the env var", func(t *testing.T) {
+		t.Setenv("AUTH0_API_CLIENT_TIMEOUT", "60")
+
+		value, err := envDefaultIntFunc("AUTH0_API_CLIENT_TIMEOUT", 30)()
+		require.NoError(t, err)
+		assert.Equal(t, 60, value)
+	})
+
+	t.Run("it fails when the env var is not a number", func(t *testing.T) {
+		t.Setenv("AUTH0_API_CLIENT_TIMEOUT", "forever")
+
+		_, err := envDefaultIntFunc("AUTH0_API_CLIENT_TIMEOUT", 30)()
+		assert.Error(t, err)
+	})
+}
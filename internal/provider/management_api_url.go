@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	internalValidation "github.com/auth0/terraform-provider-auth0/internal/validation"
+)
+
+// validateManagementAPIURL ensures the management API URL is an
+// https URL made of a scheme and a host only, as the API paths
+// are always appended by the SDK.
+func validateManagementAPIURL(rawURL interface{}, key string) ([]string, []error) {
+	warnings, errs := internalValidation.IsURLWithHTTPSorEmptyString(rawURL, key)
+	if len(errs) > 0 || rawURL.(string) == "" {
+		return warnings, errs
+	}
+
+	parsedURL, _ := url.Parse(rawURL.(string))
+	if (parsedURL.Path != "" && parsedURL.Path != "/") || parsedURL.RawQuery != "" || parsedURL.Fragment != "" {
+		return warnings, []error{
+			fmt.Errorf("expected %q to only have a scheme and a host, got %v", key, rawURL),
+		}
+	}
+
+	return warnings, nil
+}
+
+// managementAPIURLTransport sends the requests built by the SDK
+// for the tenant domain to a custom management API host instead,
+// while tokens keep being requested from the tenant domain.
+type managementAPIURLTransport struct {
+	base   http.RoundTripper
+	scheme string
+	host   string
+}
+
+func newManagementAPIURLTransport(base http.RoundTripper, managementAPIURL string) (*managementAPIURLTransport, error) {
+	parsedURL, err := url.Parse(managementAPIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &managementAPIURLTransport{
+		base:   base,
+		scheme: parsedURL.Scheme,
+		host:   parsedURL.Host,
+	}, nil
+}
+
+// RoundTrip sends the request to the management API host.
+func (t *managementAPIURLTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.URL.Scheme = t.scheme
+	request.URL.Host = t.host
+	request.Host = t.host
+
+	return t.base.RoundTrip(request)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateManagementAPIURL(t *testing.T) {
+	var testCases = []struct {
+		givenURL      string
+		expectedError string
+	}{
+		{givenURL: ""},
+		{givenURL: "https://api.example.com"},
+		{givenURL: "https://api.example.com/"},
+		{givenURL: "https://api.example.com:8443"},
+		{
+			givenURL:      "http://api.example.com",
+			expectedError: "expected \"management_api_url\" to have a url with schema of: \"https\", got http://api.example.com",
+		},
+		{
+			givenURL:      "api.example.com",
+			expectedError: "expected \"management_api_url\" to have a host, got api.example.com",
+		},
+		{
+			givenURL:      "https://api.example.com/api/v2",
+			expectedError: "expected \"management_api_url\" to only have a scheme and a host, got https://api.example.com/api/v2",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.givenURL, func(t *testing.T) {
+			_, errs := validateManagementAPIURL(testCase.givenURL, "management_api_url")
+
+			if testCase.expectedError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			require.Len(t, errs, 1)
+			assert.EqualError(t, errs[0], testCase.expectedError)
+		})
+	}
+}
+
+func TestManagementAPIURLTransport(t *testing.T) {
+	var requestedHost, requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedHost = r.Host
+		requestedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	transport, err := newManagementAPIURLTransport(http.DefaultTransport, server.URL)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, "https://example.auth0.com/api/v2/clients?page=1", nil)
+	require.NoError(t, err)
+
+	response, err := transport.RoundTrip(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, server.Listener.Addr().String(), requestedHost)
+	assert.Equal(t, "/api/v2/clients", requestedPath)
+	assert.Equal(t, "example.auth0.com", request.URL.Host, "the original request must not be modified")
+}
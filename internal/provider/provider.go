@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
+	"unicode"
 
-	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/auth0/terraform-provider-auth0/internal/auth0/action"
 	"github.com/auth0/terraform-provider-auth0/internal/auth0/attackprotection"
@@ -28,10 +31,17 @@ import (
 	"github.com/auth0/terraform-provider-auth0/internal/auth0/rule"
 	"github.com/auth0/terraform-provider-auth0/internal/auth0/tenant"
 	"github.com/auth0/terraform-provider-auth0/internal/auth0/user"
+	internalDebug "github.com/auth0/terraform-provider-auth0/internal/debug"
+	"github.com/auth0/terraform-provider-auth0/internal/ratelimit"
 )
 
 var version = "dev"
 
+var errIncompleteClientCredentials = fmt.Errorf(
+	"both the client ID and the client secret must be specified, " +
+		"either directly or through `client_id_file` and `client_secret_file`",
+)
+
 // New returns a *schema.Provider.
 func New() *schema.Provider {
 	provider := &schema.Provider{
@@ -54,25 +64,41 @@ func New() *schema.Provider {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("AUTH0_CLIENT_ID", nil),
-				RequiredWith:  []string{"client_secret"},
 				ConflictsWith: []string{"api_token"},
 				Description: "Your Auth0 client ID. " +
 					"It can also be sourced from the `AUTH0_CLIENT_ID` environment variable.",
 			},
+			"client_id_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("AUTH0_CLIENT_ID_FILE", nil),
+				ConflictsWith: []string{"api_token"},
+				Description: "The path to a file holding your Auth0 client ID, used when `client_id` is not set. " +
+					"Trailing whitespace and newlines are trimmed. " +
+					"It can also be sourced from the `AUTH0_CLIENT_ID_FILE` environment variable.",
+			},
 			"client_secret": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("AUTH0_CLIENT_SECRET", nil),
-				RequiredWith:  []string{"client_id"},
 				ConflictsWith: []string{"api_token"},
 				Description: "Your Auth0 client secret. " +
 					"It can also be sourced from the `AUTH0_CLIENT_SECRET` environment variable.",
 			},
+			"client_secret_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("AUTH0_CLIENT_SECRET_FILE", nil),
+				ConflictsWith: []string{"api_token"},
+				Description: "The path to a file holding your Auth0 client secret, used when `client_secret` " +
+					"is not set, such as a mounted Kubernetes secret. Trailing whitespace and newlines are trimmed. " +
+					"It can also be sourced from the `AUTH0_CLIENT_SECRET_FILE` environment variable.",
+			},
 			"api_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("AUTH0_API_TOKEN", nil),
-				ConflictsWith: []string{"client_id", "client_secret"},
+				ConflictsWith: []string{"client_id", "client_id_file", "client_secret", "client_secret_file"},
 				Description: "Your Auth0 [management api access token]" +
 					"(https://auth0.com/docs/security/tokens/access-tokens/management-api-access-tokens). " +
 					"It can also be sourced from the `AUTH0_API_TOKEN` environment variable. " +
@@ -89,7 +115,50 @@ func New() *schema.Provider {
 					}
 					return v == "1" || v == "true" || v == "on", nil
 				},
-				Description: "Indicates whether to turn on debug mode.",
+				Description: "Indicates whether to turn on debug mode. When on, every request sent to the " +
+					"Management API and its response are logged at the `DEBUG` level (`TF_LOG=DEBUG`), " +
+					"with credentials and sensitive fields such as `client_secret` and `twilio_token` redacted. " +
+					"It can also be sourced from the `AUTH0_DEBUG` environment variable.",
+			},
+			"rate_limit_max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  envDefaultIntFunc("AUTH0_RATE_LIMIT_MAX_RETRIES", ratelimit.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The number of times a request rejected by the Management API for exceeding " +
+					"the rate limit (429) is retried before failing. Retries honor the `Retry-After` and " +
+					"`X-RateLimit-Reset` response headers and otherwise back off exponentially. " +
+					"It can also be sourced from the `AUTH0_RATE_LIMIT_MAX_RETRIES` environment variable. " +
+					"Defaults to `10`.",
+			},
+			"api_client_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  envDefaultIntFunc("AUTH0_API_CLIENT_TIMEOUT", defaultAPIClientTimeout),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The time limit in seconds for a request to the Management API, including the time " +
+					"spent waiting on rate limit retries. It can also be sourced from the " +
+					"`AUTH0_API_CLIENT_TIMEOUT` environment variable. Defaults to `0`, which means no time limit.",
+			},
+			"api_client_max_idle_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  envDefaultIntFunc("AUTH0_API_CLIENT_MAX_IDLE_CONNECTIONS", defaultAPIClientMaxIdleConnections),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The number of idle connections to the Management API kept open for reuse. " +
+					"Raising it helps when applying many resources concurrently, such as with a high `-parallelism`. " +
+					"It can also be sourced from the `AUTH0_API_CLIENT_MAX_IDLE_CONNECTIONS` environment variable. " +
+					"Defaults to `100`.",
+			},
+			"management_api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AUTH0_MANAGEMENT_API_URL", nil),
+				ValidateFunc: validateManagementAPIURL,
+				Description: "The base URL of the Management API, such as `https://api.example.com`, " +
+					"when it's not served from the `domain`, for example on Private Cloud deployments. " +
+					"Tokens are still requested from the `domain`. " +
+					"It can also be sourced from the `AUTH0_MANAGEMENT_API_URL` environment variable.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -103,6 +172,7 @@ func New() *schema.Provider {
 			"auth0_global_client":              client.NewGlobalResource(),
 			"auth0_connection":                 connection.NewResource(),
 			"auth0_connection_client":          connection.NewClientResource(),
+			"auth0_connection_clients":         connection.NewClientsResource(),
 			"auth0_custom_domain":              customdomain.NewResource(),
 			"auth0_custom_domain_verification": customdomain.NewVerificationResource(),
 			"auth0_email":                      email.NewResource(),
@@ -123,17 +193,21 @@ func New() *schema.Provider {
 			"auth0_user":                       user.NewResource(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"auth0_action":            action.NewDataSource(),
 			"auth0_attack_protection": attackprotection.NewDataSource(),
 			"auth0_branding":          branding.NewDataSource(),
 			"auth0_branding_theme":    branding.NewThemeDataSource(),
 			"auth0_client":            client.NewDataSource(),
 			"auth0_global_client":     client.NewGlobalDataSource(),
 			"auth0_connection":        connection.NewDataSource(),
+			"auth0_connections":       connection.NewConnectionsDataSource(),
 			"auth0_custom_domain":     customdomain.NewDataSource(),
+			"auth0_guardian":          guardian.NewDataSource(),
 			"auth0_organization":      organization.NewDataSource(),
 			"auth0_resource_server":   resourceserver.NewDataSource(),
 			"auth0_role":              role.NewDataSource(),
 			"auth0_tenant":            tenant.NewDataSource(),
+			"auth0_trigger_actions":   action.NewTriggerActionsDataSource(),
 			"auth0_user":              user.NewDataSource(),
 		},
 	}
@@ -149,16 +223,7 @@ func configureProvider(
 	terraformVersion *string,
 ) func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		sdkVersion := auth0.Version
-		terraformSDKVersion := meta.SDKVersionString()
-
-		userAgent := fmt.Sprintf(
-			"Terraform-Provider-Auth0/%s (Go-Auth0-SDK/%s; Terraform-SDK/%s; Terraform/%s)",
-			version,
-			sdkVersion,
-			terraformSDKVersion,
-			*terraformVersion,
-		)
+		userAgent := formatUserAgent(*terraformVersion)
 
 		domain := data.Get("domain").(string)
 		audience := data.Get("audience").(string)
@@ -166,10 +231,26 @@ func configureProvider(
 		clientID := data.Get("client_id").(string)
 		clientSecret := data.Get("client_secret").(string)
 		apiToken := data.Get("api_token").(string)
+		rateLimitMaxRetries := data.Get("rate_limit_max_retries").(int)
+		managementAPIURL := data.Get("management_api_url").(string)
+		apiClientTimeout := data.Get("api_client_timeout").(int)
+		apiClientMaxIdleConnections := data.Get("api_client_max_idle_connections").(int)
 
 		authenticationOption := management.WithStaticToken(apiToken)
 		// If api_token is not specified, authenticate with client ID and client secret.
 		if apiToken == "" {
+			var err error
+			if clientID, err = readCredential(clientID, data.Get("client_id_file").(string)); err != nil {
+				return nil, diag.Errorf("failed to read the client ID from client_id_file: %s", err)
+			}
+			if clientSecret, err = readCredential(clientSecret, data.Get("client_secret_file").(string)); err != nil {
+				return nil, diag.Errorf("failed to read the client secret from client_secret_file: %s", err)
+			}
+
+			if (clientID == "") != (clientSecret == "") {
+				return nil, diag.FromErr(errIncompleteClientCredentials)
+			}
+
 			authenticationOption = management.WithClientCredentials(clientID, clientSecret)
 
 			if audience != "" {
@@ -181,10 +262,30 @@ func configureProvider(
 			}
 		}
 
+		// The SDK's own debug mode dumps requests and responses as they
+		// are, credentials included, so a redacting transport is used instead.
+		var transport http.RoundTripper = newHTTPTransport(apiClientMaxIdleConnections)
+		if debug {
+			transport = internalDebug.NewTransport(transport)
+		}
+
+		if managementAPIURL != "" {
+			managementAPIURLTransport, err := newManagementAPIURLTransport(transport, managementAPIURL)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			transport = managementAPIURLTransport
+		}
+
+		transport = newRequestIDTransport(transport)
+
 		apiClient, err := management.New(domain,
 			authenticationOption,
-			management.WithDebug(debug),
 			management.WithUserAgent(userAgent),
+			management.WithClient(&http.Client{
+				Transport: ratelimit.NewTransport(transport, rateLimitMaxRetries),
+				Timeout:   time.Duration(apiClientTimeout) * time.Second,
+			}),
 		)
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -193,3 +294,18 @@ func configureProvider(
 		return apiClient, nil
 	}
 }
+
+// readCredential returns the given credential, or the content of the file
+// at the given path without its trailing whitespace if the credential is empty.
+func readCredential(credential, path string) (string, error) {
+	if credential != "" || path == "" {
+		return credential, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRightFunc(string(content), unicode.IsSpace), nil
+}
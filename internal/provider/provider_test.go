@@ -1,14 +1,19 @@
 package provider
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMain(m *testing.M) {
@@ -56,22 +61,16 @@ func TestProvider_configValidation(t *testing.T) {
 		expectedErrors diag.Diagnostics
 	}{
 		{
-			name:           "missing client id",
-			resourceConfig: map[string]interface{}{"domain": "test", "client_secret": "test"},
+			name:           "conflicting credential files and management token",
+			resourceConfig: map[string]interface{}{"domain": "test", "client_secret_file": "/secret", "api_token": "test"},
 			expectedErrors: diag.Diagnostics{
 				diag.Diagnostic{
-					Summary: "RequiredWith",
-					Detail:  "\"client_secret\": all of `client_id,client_secret` must be specified",
+					Summary: "ConflictsWith",
+					Detail:  "\"api_token\": conflicts with client_secret_file",
 				},
-			},
-		},
-		{
-			name:           "missing client secret",
-			resourceConfig: map[string]interface{}{"domain": "test", "client_id": "test"},
-			expectedErrors: diag.Diagnostics{
 				diag.Diagnostic{
-					Summary: "RequiredWith",
-					Detail:  "\"client_id\": all of `client_id,client_secret` must be specified",
+					Summary: "ConflictsWith",
+					Detail:  "\"client_secret_file\": conflicts with api_token",
 				},
 			},
 		},
@@ -102,6 +101,11 @@ func TestProvider_configValidation(t *testing.T) {
 			resourceConfig: map[string]interface{}{"domain": "valid_domain", "client_id": "test", "client_secret": "test"},
 			expectedErrors: nil,
 		},
+		{
+			name:           "valid auth0 client with credential files",
+			resourceConfig: map[string]interface{}{"domain": "valid_domain", "client_id": "test", "client_secret_file": "/secret"},
+			expectedErrors: nil,
+		},
 		{
 			name:           "valid auth0 token",
 			resourceConfig: map[string]interface{}{"domain": "valid_domain", "api_token": "test"},
@@ -127,6 +131,90 @@ func TestProvider_configValidation(t *testing.T) {
 	}
 }
 
+func TestReadCredential(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "client_secret")
+	err := os.WriteFile(path, []byte("file-secret \n\n"), 0600)
+	require.NoError(t, err)
+
+	credential, err := readCredential("", path)
+	require.NoError(t, err)
+	assert.Equal(t, "file-secret", credential)
+
+	credential, err = readCredential("direct-secret", path)
+	require.NoError(t, err)
+	assert.Equal(t, "direct-secret", credential, "the direct value takes precedence over the file")
+
+	credential, err = readCredential("", "")
+	require.NoError(t, err)
+	assert.Empty(t, credential)
+
+	_, err = readCredential("", filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestProvider_configureClientCredentials(t *testing.T) {
+	for _, name := range []string{
+		"AUTH0_API_TOKEN",
+		"AUTH0_CLIENT_ID",
+		"AUTH0_CLIENT_ID_FILE",
+		"AUTH0_CLIENT_SECRET",
+		"AUTH0_CLIENT_SECRET_FILE",
+	} {
+		t.Setenv(name, "")
+	}
+
+	secretPath := filepath.Join(t.TempDir(), "client_secret")
+	err := os.WriteFile(secretPath, []byte("file-secret\n"), 0600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		givenConfig   map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:        "client secret read from a file",
+			givenConfig: map[string]interface{}{"domain": "example.auth0.com", "client_id": "test", "client_secret_file": secretPath},
+		},
+		{
+			name:          "missing client secret",
+			givenConfig:   map[string]interface{}{"domain": "example.auth0.com", "client_id": "test"},
+			expectedError: errIncompleteClientCredentials.Error(),
+		},
+		{
+			name:          "missing client id",
+			givenConfig:   map[string]interface{}{"domain": "example.auth0.com", "client_secret": "test"},
+			expectedError: errIncompleteClientCredentials.Error(),
+		},
+		{
+			name: "unreadable client secret file",
+			givenConfig: map[string]interface{}{
+				"domain":             "example.auth0.com",
+				"client_id":          "test",
+				"client_secret_file": filepath.Join(t.TempDir(), "missing"),
+			},
+			expectedError: "failed to read the client secret from client_secret_file",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			terraformVersion := "1.4.0"
+			data := schema.TestResourceDataRaw(t, New().Schema, testCase.givenConfig)
+
+			_, diagnostics := configureProvider(&terraformVersion)(context.Background(), data)
+
+			if testCase.expectedError == "" {
+				assert.False(t, diagnostics.HasError(), "unexpected errors: %v", diagnostics)
+				return
+			}
+
+			require.Len(t, diagnostics, 1)
+			assert.Contains(t, diagnostics[0].Summary, testCase.expectedError)
+		})
+	}
+}
+
 func sortErrors(errs diag.Diagnostics) {
 	sort.Slice(errs, func(i, j int) bool {
 		return errs[i].Detail < errs[j].Detail
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// auth0RequestIDHeader holds the ID Auth0 assigns to each request,
+// which support needs to trace a failed request.
+const auth0RequestIDHeader = "X-Auth0-RequestId"
+
+// requestIDTransport appends the Auth0 request ID to the message of the
+// API error responses, as the SDK drops the response headers when building
+// a management.Error, so that it ends up in the error diagnostics.
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+func newRequestIDTransport(base http.RoundTripper) *requestIDTransport {
+	return &requestIDTransport{
+		base: base,
+	}
+}
+
+// RoundTrip sends the request and adds the request ID to error responses.
+func (t *requestIDTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.base.RoundTrip(request)
+	if err != nil || response.StatusCode < http.StatusBadRequest {
+		return response, err
+	}
+
+	requestID := response.Header.Get(auth0RequestIDHeader)
+	if requestID == "" {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	body = addRequestIDToErrorMessage(body, requestID)
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+	response.Header.Del("Content-Length")
+
+	return response, nil
+}
+
+func addRequestIDToErrorMessage(body []byte, requestID string) []byte {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body
+	}
+
+	message, _ := payload["message"].(string)
+	payload["message"] = strings.TrimSpace(fmt.Sprintf("%s (request ID: %s)", message, requestID))
+
+	updatedBody, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+
+	return updatedBody
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalError "github.com/auth0/terraform-provider-auth0/internal/error"
+)
+
+func TestRequestIDTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(auth0RequestIDHeader, "a1b2c3d4")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The connection does not exist"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(
+		server.Listener.Addr().String(),
+		management.WithStaticToken("token"),
+		management.WithClient(&http.Client{
+			Transport: newRequestIDTransport(server.Client().Transport),
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = api.Connection.Read("con_123")
+	require.Error(t, err)
+
+	diagnostics := diag.FromErr(err)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "404 Not Found: The connection does not exist (request ID: a1b2c3d4)", diagnostics[0].Summary)
+	assert.True(t, internalError.IsStatusNotFound(err), "the error must still be a management error")
+}
+
+func TestAddRequestIDToErrorMessage(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenBody    string
+		expectedBody string
+	}{
+		{
+			name:         "error with a message",
+			givenBody:    `{"statusCode":400,"error":"Bad Request","message":"Payload validation error"}`,
+			expectedBody: `{"error":"Bad Request","message":"Payload validation error (request ID: a1b2c3d4)","statusCode":400}`,
+		},
+		{
+			name:         "error without a message",
+			givenBody:    `{"statusCode":500,"error":"Internal Server Error"}`,
+			expectedBody: `{"error":"Internal Server Error","message":"(request ID: a1b2c3d4)","statusCode":500}`,
+		},
+		{
+			name:         "error that is not json",
+			givenBody:    `Bad Gateway`,
+			expectedBody: `Bad Gateway`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actualBody := addRequestIDToErrorMessage([]byte(testCase.givenBody), "a1b2c3d4")
+
+			assert.Equal(t, testCase.expectedBody, string(actualBody))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/auth0/go-auth0"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
+)
+
+// providerVersion returns the version set at build time through ldflags,
+// falling back to the module version for builds made with `go install`.
+func providerVersion() string {
+	if version != "dev" {
+		return version
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok || buildInfo.Main.Version == "" || buildInfo.Main.Version == "(devel)" {
+		return version
+	}
+
+	return buildInfo.Main.Version
+}
+
+// formatUserAgent identifies the provider, the SDKs and the Terraform
+// version in the requests, to ease the triage of support tickets.
+func formatUserAgent(terraformVersion string) string {
+	return fmt.Sprintf(
+		"Terraform-Provider-Auth0/%s (Go-Auth0-SDK/%s; Terraform-SDK/%s; Terraform/%s)",
+		providerVersion(),
+		auth0.Version,
+		meta.SDKVersionString(),
+		terraformVersion,
+	)
+}
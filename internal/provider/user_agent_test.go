@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUserAgent(t *testing.T) {
+	assert.Regexp(
+		t,
+		regexp.MustCompile(`^Terraform-Provider-Auth0/\S+ \(Go-Auth0-SDK/\S+; Terraform-SDK/\S+; Terraform/1\.4\.0\)$`),
+		formatUserAgent("1.4.0"),
+	)
+}
@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a rate limited
+	// request is retried when no other budget is configured.
+	DefaultMaxRetries = 10
+
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// Transport retries the requests rejected by the Auth0 Management API
+// with a 429 status code, waiting for as long as the "Retry-After" or
+// "X-RateLimit-Reset" headers ask for or, when none is set, backing off
+// exponentially. Once the retry budget is exhausted an error is returned,
+// so the failure surfaces instead of the request being retried forever.
+type Transport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+}
+
+// NewTransport wraps the base transport, retrying
+// rate limited requests up to maxRetries times.
+func NewTransport(base http.RoundTripper, maxRetries int) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		base:       base,
+		maxRetries: maxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		now:        time.Now,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, retrying it while rate limited.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := t.base.RoundTrip(request)
+		if err != nil || response.StatusCode != http.StatusTooManyRequests {
+			return response, err
+		}
+
+		if request.Body != nil && request.GetBody == nil {
+			// The body was consumed and can't be replayed.
+			return response, nil
+		}
+
+		if attempt >= t.maxRetries {
+			closeResponseBody(response)
+			return nil, fmt.Errorf(
+				"%s %s: still rate limited after %d retries",
+				request.Method,
+				request.URL.Path,
+				t.maxRetries,
+			)
+		}
+
+		wait := t.retryDelay(response.Header, attempt)
+		closeResponseBody(response)
+
+		log.Printf(
+			"[DEBUG] Rate limited on %s %s, retrying in %s (attempt %d of %d)",
+			request.Method,
+			request.URL.Path,
+			wait,
+			attempt+1,
+			t.maxRetries,
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+
+		request, err = rewindRequest(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying, preferring the
+// delay asked for by the API over the exponential backoff.
+func (t *Transport) retryDelay(header http.Header, attempt int) time.Duration {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+			return nonNegative(time.Duration(seconds) * time.Second)
+		}
+
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(date.Sub(t.now()))
+		}
+	}
+
+	if resetAt := header.Get("X-RateLimit-Reset"); resetAt != "" {
+		if resetAtUnix, err := strconv.ParseInt(resetAt, 10, 64); err == nil {
+			return nonNegative(time.Unix(resetAtUnix, 0).Sub(t.now()))
+		}
+	}
+
+	backoff := t.minBackoff
+	for i := 0; i < attempt && backoff < t.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > t.maxBackoff {
+		return t.maxBackoff
+	}
+
+	return backoff
+}
+
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	if request.Body == nil || request.GetBody == nil {
+		return request, nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewoundRequest := request.Clone(request.Context())
+	rewoundRequest.Body = body
+
+	return rewoundRequest, nil
+}
+
+func closeResponseBody(response *http.Response) {
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+}
+
+func nonNegative(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+
+	return duration
+}
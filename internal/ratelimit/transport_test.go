@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportRetriesRateLimitedRequests(t *testing.T) {
+	var requestBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requestBodies = append(requestBodies, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if len(requestBodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"statusCode":429,"error":"Too Many Requests","message":"Global limit has been reached"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":       "con_123",
+			"name":     "my-connection",
+			"strategy": "auth0",
+		})
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := management.New(
+		server.URL,
+		management.WithInsecure(),
+		management.WithClient(&http.Client{Transport: NewTransport(http.DefaultTransport, 3)}),
+	)
+	require.NoError(t, err)
+
+	connection := &management.Connection{
+		Name:     auth0.String("my-connection"),
+		Strategy: auth0.String("auth0"),
+	}
+	err = api.Connection.Create(connection)
+	require.NoError(t, err)
+
+	assert.Equal(t, "con_123", connection.GetID())
+	require.Len(t, requestBodies, 2)
+	assert.Equal(t, requestBodies[0], requestBodies[1])
+	assert.Contains(t, requestBodies[1], `"name":"my-connection"`)
+}
+
+func TestTransportGivesUpOnceTheRetryBudgetIsExhausted(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, 2)}
+
+	response, err := client.Get(server.URL + "/api/v2/connections")
+	if response != nil {
+		_ = response.Body.Close()
+	}
+
+	assert.ErrorContains(t, err, "GET /api/v2/connections: still rate limited after 2 retries")
+	assert.Equal(t, 3, requests)
+}
+
+func TestTransportRetryDelay(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	transport := NewTransport(nil, DefaultMaxRetries)
+	transport.now = func() time.Time { return now }
+
+	var testCases = []struct {
+		name     string
+		header   http.Header
+		attempt  int
+		expected time.Duration
+	}{
+		{
+			name:     "Retry-After in seconds",
+			header:   http.Header{"Retry-After": []string{"3"}},
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "Retry-After as a date",
+			header:   http.Header{"Retry-After": []string{now.Add(7 * time.Second).Format(http.TimeFormat)}},
+			expected: 7 * time.Second,
+		},
+		{
+			name:     "X-RateLimit-Reset in the future",
+			header:   http.Header{"X-Ratelimit-Reset": []string{"1672574405"}},
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "X-RateLimit-Reset in the past",
+			header:   http.Header{"X-Ratelimit-Reset": []string{"1672574395"}},
+			expected: 0,
+		},
+		{
+			name:     "exponential backoff on the first attempt",
+			header:   http.Header{},
+			attempt:  0,
+			expected: defaultMinBackoff,
+		},
+		{
+			name:     "exponential backoff on the third attempt",
+			header:   http.Header{},
+			attempt:  2,
+			expected: 4 * defaultMinBackoff,
+		},
+		{
+			name:     "exponential backoff is capped",
+			header:   http.Header{},
+			attempt:  20,
+			expected: defaultMaxBackoff,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := transport.retryDelay(testCase.header, testCase.attempt)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
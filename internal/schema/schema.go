@@ -6,6 +6,10 @@ import (
 
 // TransformResourceToDataSource is a recursive function that
 // converts an existing Resource schema to a DataSource schema.
+//
+// Terraform handles computed only lists and sets as a single attribute,
+// so the sensitivity of their nested attributes is lost in the plan output.
+// Data sources need to blank such nested secrets when reading them.
 func TransformResourceToDataSource(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
 	dataSourceSchema := make(map[string]*schema.Schema, len(resourceSchema))
 
@@ -25,6 +29,7 @@ func TransformResourceToDataSource(resourceSchema map[string]*schema.Schema) map
 			ForceNew:    false,
 			Required:    false,
 			Optional:    false,
+			Sensitive:   definition.Sensitive,
 			Description: definition.Description,
 			Type:        definition.Type,
 			Set:         definition.Set,
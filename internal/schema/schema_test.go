@@ -100,6 +100,56 @@ func assertDataSourceSchemaDefinitionsAreCorrect(
 	}
 }
 
+func TestTransformResourceToDataSourceKeepsAttributesSensitive(t *testing.T) {
+	var newMockResourceSchema = map[string]*schema.Schema{
+		"secret_prop": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Some sensitive property.",
+		},
+		"plain_prop": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Some property.",
+		},
+		"list_prop": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Some list property with a nested sensitive property.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"inner_list": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Description for list_prop.inner_list.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"inner_secret": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Sensitive:   true,
+									Description: "Description for list_prop.inner_list.inner_secret.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dataSourceSchema := TransformResourceToDataSource(newMockResourceSchema)
+
+	assert.True(t, dataSourceSchema["secret_prop"].Sensitive)
+	assert.False(t, dataSourceSchema["plain_prop"].Sensitive)
+	assert.False(t, dataSourceSchema["list_prop"].Sensitive)
+
+	innerList := dataSourceSchema["list_prop"].Elem.(*schema.Resource).Schema["inner_list"]
+	assert.False(t, innerList.Sensitive)
+	assert.True(t, innerList.Elem.(*schema.Resource).Schema["inner_secret"].Sensitive)
+}
+
 func TestSetExistingAttributesAsOptional(t *testing.T) {
 	var newMockResourceSchema = map[string]*schema.Schema{
 		"string_prop": {
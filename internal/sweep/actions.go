@@ -0,0 +1,131 @@
+package sweep
+
+import (
+	"log"
+	"strings"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// legacyActionTestPrefixes are the prefixes with which the action
+// acceptance tests name their resources, as they predate testPrefix.
+var legacyActionTestPrefixes = []string{"Test Action ", "Test Trigger Binding "}
+
+// Actions will run a test sweeper to remove all Auth0 Actions created through tests.
+//
+// Actions can't be deleted while they are bound to a trigger, so the test actions
+// are first unbound from every trigger, keeping any other binding in place.
+// Only deployed actions can be bound, drafts are deleted right away.
+func Actions() {
+	resource.AddTestSweepers("auth0_action", &resource.Sweeper{
+		Name: "auth0_action",
+		F: func(_ string) error {
+			api, err := auth0API()
+			if err != nil {
+				return err
+			}
+
+			triggers, err := api.Action.Triggers()
+			if err != nil {
+				return err
+			}
+
+			var result *multierror.Error
+			for _, trigger := range triggers.Triggers {
+				result = multierror.Append(result, unbindTestActions(api, trigger.GetID()))
+			}
+
+			actions, err := listAll(func(page int) ([]*management.Action, bool, error) {
+				actionList, err := api.Action.List(management.Page(page), management.PerPage(100))
+				if err != nil {
+					return nil, false, err
+				}
+
+				return actionList.Actions, actionList.HasNext(), nil
+			})
+			if err != nil {
+				return multierror.Append(result, err)
+			}
+
+			for _, action := range actions {
+				log.Printf("[DEBUG] ➝ %s", action.GetName())
+
+				if isTestAction(action.GetName()) {
+					result = multierror.Append(
+						result,
+						deleteResource(action.GetID(), action.GetName(), func() error {
+							return api.Action.Delete(action.GetID())
+						}),
+					)
+				}
+			}
+
+			return result.ErrorOrNil()
+		},
+	})
+}
+
+// unbindTestActions removes the test actions from the bindings of
+// the trigger, leaving the bindings of any other action untouched.
+func unbindTestActions(api *management.Management, triggerID string) error {
+	bindings, err := listAll(func(page int) ([]*management.ActionBinding, bool, error) {
+		bindingList, err := api.Action.Bindings(triggerID, management.Page(page), management.PerPage(50))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return bindingList.Bindings, bindingList.HasNext(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	remainingBindings := make([]*management.ActionBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		if isTestAction(binding.GetAction().GetName()) {
+			if isDryRun() {
+				log.Printf("[INFO] would unbind %s (%s) from %s", binding.GetAction().GetName(), binding.GetAction().GetID(), triggerID)
+			} else {
+				log.Printf("[DEBUG] ✗ %s bound to %s", binding.GetAction().GetName(), triggerID)
+			}
+			continue
+		}
+
+		refType := "action_id"
+		actionID := binding.GetAction().GetID()
+		remainingBindings = append(remainingBindings, &management.ActionBinding{
+			Ref: &management.ActionBindingReference{
+				Type:  &refType,
+				Value: &actionID,
+			},
+			DisplayName: binding.DisplayName,
+		})
+	}
+
+	if len(remainingBindings) == len(bindings) || isDryRun() {
+		return nil
+	}
+
+	return api.Action.UpdateBindings(triggerID, remainingBindings)
+}
+
+// isTestAction returns true if the name belongs to an action created through tests.
+func isTestAction(name string) bool {
+	if isTestResource(name) {
+		return true
+	}
+
+	if testPrefix() != defaultTestPrefix {
+		return false
+	}
+
+	for _, prefix := range legacyActionTestPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
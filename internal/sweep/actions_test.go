@@ -0,0 +1,51 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTestAction(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		givenPrefix    string
+		givenName      string
+		expectedResult bool
+	}{
+		{
+			name:           "it matches the default prefix",
+			givenName:      "Acceptance-Test-Action-TestAccAction",
+			expectedResult: true,
+		},
+		{
+			name:           "it matches the actions created by the action tests",
+			givenName:      "Test Action TestAccAction",
+			expectedResult: true,
+		},
+		{
+			name:           "it matches the actions created by the trigger binding tests",
+			givenName:      "Test Trigger Binding Foo TestAccTriggerBinding",
+			expectedResult: true,
+		},
+		{
+			name:           "it does not match other actions",
+			givenName:      "Add roles to tokens",
+			expectedResult: false,
+		},
+		{
+			name:           "it only matches the custom prefix when one is set",
+			givenPrefix:    "tf-test-",
+			givenName:      "Test Action TestAccAction",
+			expectedResult: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("AUTH0_TEST_PREFIX", testCase.givenPrefix)
+
+			assert.Equal(t, testCase.expectedResult, isTestAction(testCase.givenName))
+		})
+	}
+}
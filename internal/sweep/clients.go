@@ -19,29 +19,30 @@ func Clients() {
 				return err
 			}
 
-			var page int
-			var result *multierror.Error
-			for {
+			clients, err := listAll(func(page int) ([]*management.Client, bool, error) {
 				clientList, err := api.Client.List(management.Page(page))
 				if err != nil {
-					return err
+					return nil, false, err
 				}
 
-				for _, client := range clientList.Clients {
-					log.Printf("[DEBUG] ➝ %s", client.GetName())
+				return clientList.Clients, clientList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
 
-					if strings.Contains(client.GetName(), "Test") {
-						result = multierror.Append(
-							result,
-							api.Client.Delete(client.GetClientID()),
-						)
-						log.Printf("[DEBUG] ✗ %s", client.GetName())
-					}
-				}
-				if !clientList.HasNext() {
-					break
+			var result *multierror.Error
+			for _, client := range clients {
+				log.Printf("[DEBUG] ➝ %s", client.GetName())
+
+				if strings.Contains(client.GetName(), "Test") {
+					result = multierror.Append(
+						result,
+						deleteResource(client.GetClientID(), client.GetName(), func() error {
+							return api.Client.Delete(client.GetClientID())
+						}),
+					)
 				}
-				page++
 			}
 
 			return result.ErrorOrNil()
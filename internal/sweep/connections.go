@@ -2,7 +2,6 @@ package sweep
 
 import (
 	"log"
-	"strings"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
@@ -19,32 +18,36 @@ func Connections() {
 				return err
 			}
 
-			var page int
-			var result *multierror.Error
-			for {
+			// Collect the connections across all pages before deleting any,
+			// as deleting while paginating shifts the pages and skips some.
+			connections, err := listAll(func(page int) ([]*management.Connection, bool, error) {
 				connectionList, err := api.Connection.List(
 					management.IncludeFields("id", "name"),
 					management.Page(page),
+					management.PerPage(100),
 				)
 				if err != nil {
-					return err
+					return nil, false, err
 				}
 
-				for _, connection := range connectionList.Connections {
-					log.Printf("[DEBUG] ➝ %s", connection.GetName())
+				return connectionList.Connections, connectionList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
 
-					if strings.Contains(connection.GetName(), "Test") {
-						result = multierror.Append(
-							result,
-							api.Connection.Delete(connection.GetID()),
-						)
-						log.Printf("[DEBUG] ✗ %s", connection.GetName())
-					}
-				}
-				if !connectionList.HasNext() {
-					break
+			var result *multierror.Error
+			for _, connection := range connections {
+				log.Printf("[DEBUG] ➝ %s", connection.GetName())
+
+				if isTestResource(connection.GetName()) {
+					result = multierror.Append(
+						result,
+						deleteResource(connection.GetID(), connection.GetName(), func() error {
+							return api.Connection.Delete(connection.GetID())
+						}),
+					)
 				}
-				page++
 			}
 
 			return result.ErrorOrNil()
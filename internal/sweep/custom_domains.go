@@ -30,10 +30,10 @@ func CustomDomains() {
 				if strings.Contains(domain.GetDomain(), "auth.uat.terraform-provider-auth0.com") {
 					result = multierror.Append(
 						result,
-						api.CustomDomain.Delete(domain.GetID()),
+						deleteResource(domain.GetID(), domain.GetDomain(), func() error {
+							return api.CustomDomain.Delete(domain.GetID())
+						}),
 					)
-
-					log.Printf("[DEBUG] ✗ %s", domain.GetDomain())
 				}
 			}
 
@@ -0,0 +1,26 @@
+package sweep
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// isDryRun returns true if the AUTH0_SWEEP_DRY_RUN env var is set to a true
+// value, in which case the sweepers only log what they would delete.
+func isDryRun() bool {
+	dryRun, _ := strconv.ParseBool(os.Getenv("AUTH0_SWEEP_DRY_RUN"))
+	return dryRun
+}
+
+// deleteResource calls deleteFunc to remove the resource with the given
+// ID and name, unless running in dry-run mode, where it's only logged.
+func deleteResource(id, name string, deleteFunc func() error) error {
+	if isDryRun() {
+		log.Printf("[INFO] would delete %s (%s)", name, id)
+		return nil
+	}
+
+	log.Printf("[DEBUG] ✗ %s", name)
+	return deleteFunc()
+}
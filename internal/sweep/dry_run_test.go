@@ -0,0 +1,58 @@
+package sweep
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDryRun(t *testing.T) {
+	var testCases = []struct {
+		givenValue     string
+		expectedResult bool
+	}{
+		{givenValue: "", expectedResult: false},
+		{givenValue: "false", expectedResult: false},
+		{givenValue: "0", expectedResult: false},
+		{givenValue: "true", expectedResult: true},
+		{givenValue: "1", expectedResult: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.givenValue, func(t *testing.T) {
+			t.Setenv("AUTH0_SWEEP_DRY_RUN", testCase.givenValue)
+
+			assert.Equal(t, testCase.expectedResult, isDryRun())
+		})
+	}
+}
+
+func TestDeleteResource(t *testing.T) {
+	t.Run("it deletes the resource", func(t *testing.T) {
+		t.Setenv("AUTH0_SWEEP_DRY_RUN", "")
+
+		expectedErr := errors.New("failed to delete")
+		deleted := false
+		err := deleteResource("con_123", "Acceptance-Test-Connection", func() error {
+			deleted = true
+			return expectedErr
+		})
+
+		assert.True(t, deleted)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("it does not delete the resource in dry-run mode", func(t *testing.T) {
+		t.Setenv("AUTH0_SWEEP_DRY_RUN", "true")
+
+		deleted := false
+		err := deleteResource("con_123", "Acceptance-Test-Connection", func() error {
+			deleted = true
+			return nil
+		})
+
+		assert.False(t, deleted)
+		assert.NoError(t, err)
+	})
+}
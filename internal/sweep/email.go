@@ -1,6 +1,8 @@
 package sweep
 
 import (
+	"log"
+
 	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,7 +17,9 @@ func Email() {
 			if err != nil {
 				return err
 			}
-			return api.EmailProvider.Delete()
+			return deleteResource("email_provider", "email provider", func() error {
+				return api.EmailProvider.Delete()
+			})
 		},
 	})
 }
@@ -29,6 +33,11 @@ func EmailTemplates() {
 			if err != nil {
 				return
 			}
+			if isDryRun() {
+				log.Printf("[INFO] would disable email template welcome_email")
+				return
+			}
+
 			err = api.EmailTemplate.Update("welcome_email", &management.EmailTemplate{
 				Enabled: auth0.Bool(false),
 			})
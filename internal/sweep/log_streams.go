@@ -2,7 +2,6 @@ package sweep
 
 import (
 	"log"
-	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -27,13 +26,13 @@ func LogStreams() {
 			for _, logStream := range logStreams {
 				log.Printf("[DEBUG] ➝ %s", logStream.GetName())
 
-				if strings.Contains(logStream.GetName(), "Test") {
+				if isTestResource(logStream.GetName()) {
 					result = multierror.Append(
 						result,
-						api.LogStream.Delete(logStream.GetID()),
+						deleteResource(logStream.GetID(), logStream.GetName(), func() error {
+							return api.LogStream.Delete(logStream.GetID())
+						}),
 					)
-
-					log.Printf("[DEBUG] ✗ %v\n", logStream.GetName())
 				}
 			}
 
@@ -19,30 +19,30 @@ func Organizations() {
 				return err
 			}
 
-			var page int
-			var result *multierror.Error
-			for {
+			organizations, err := listAll(func(page int) ([]*management.Organization, bool, error) {
 				organizationList, err := api.Organization.List(management.Page(page))
 				if err != nil {
-					return err
+					return nil, false, err
 				}
 
-				for _, organization := range organizationList.Organizations {
-					log.Printf("[DEBUG] ➝ %s", organization.GetName())
-
-					if strings.Contains(organization.GetName(), "test") {
-						result = multierror.Append(
-							result,
-							api.Organization.Delete(organization.GetID()),
-						)
+				return organizationList.Organizations, organizationList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
 
-						log.Printf("[DEBUG] ✗ %s", organization.GetName())
-					}
-				}
-				if !organizationList.HasNext() {
-					break
+			var result *multierror.Error
+			for _, organization := range organizations {
+				log.Printf("[DEBUG] ➝ %s", organization.GetName())
+
+				if strings.Contains(organization.GetName(), "test") {
+					result = multierror.Append(
+						result,
+						deleteResource(organization.GetID(), organization.GetName(), func() error {
+							return api.Organization.Delete(organization.GetID())
+						}),
+					)
 				}
-				page++
 			}
 
 			return result.ErrorOrNil()
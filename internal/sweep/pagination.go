@@ -0,0 +1,20 @@
+package sweep
+
+// listAll fetches every page through the given closure and returns all of
+// the items, so that sweepers can delete them without shifting the pages
+// while they are still paginating. It stops at the first error.
+func listAll[T any](fetchPage func(page int) (items []T, hasNext bool, err error)) ([]T, error) {
+	var allItems []T
+	for page := 0; ; page++ {
+		items, hasNext, err := fetchPage(page)
+		if err != nil {
+			return nil, err
+		}
+
+		allItems = append(allItems, items...)
+
+		if !hasNext {
+			return allItems, nil
+		}
+	}
+}
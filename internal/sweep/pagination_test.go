@@ -0,0 +1,39 @@
+package sweep
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAll(t *testing.T) {
+	t.Run("it accumulates the items of every page", func(t *testing.T) {
+		pages := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+
+		var fetchedPages []int
+		items, err := listAll(func(page int) ([]string, bool, error) {
+			fetchedPages = append(fetchedPages, page)
+			return pages[page], page < len(pages)-1, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, items)
+		assert.Equal(t, []int{0, 1, 2}, fetchedPages)
+	})
+
+	t.Run("it stops and propagates the first error", func(t *testing.T) {
+		var fetchedPages []int
+		items, err := listAll(func(page int) ([]string, bool, error) {
+			fetchedPages = append(fetchedPages, page)
+			if page == 1 {
+				return nil, true, fmt.Errorf("failed to fetch page %d", page)
+			}
+			return []string{"a"}, true, nil
+		})
+
+		assert.EqualError(t, err, "failed to fetch page 1")
+		assert.Nil(t, items)
+		assert.Equal(t, []int{0, 1}, fetchedPages)
+	})
+}
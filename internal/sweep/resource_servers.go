@@ -2,9 +2,9 @@ package sweep
 
 import (
 	"log"
-	"strings"
 
 	"github.com/auth0/go-auth0/management"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -18,17 +18,37 @@ func ResourceServers() {
 				return err
 			}
 
-			fn := func(rs *management.ResourceServer) {
-				log.Printf("[DEBUG] ➝ %s", rs.GetName())
-				if strings.Contains(rs.GetName(), "Test") {
-					if err := api.ResourceServer.Delete(rs.GetID()); err != nil {
-						log.Printf("[DEBUG] Failed to delete resource server with ID: %s", rs.GetID())
-					}
-					log.Printf("[DEBUG] ✗ %s", rs.GetName())
+			resourceServers, err := listAll(func(page int) ([]*management.ResourceServer, bool, error) {
+				resourceServerList, err := api.ResourceServer.List(
+					management.IncludeFields("id", "name"),
+					management.Page(page),
+					management.PerPage(100),
+				)
+				if err != nil {
+					return nil, false, err
+				}
+
+				return resourceServerList.ResourceServers, resourceServerList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
+
+			var result *multierror.Error
+			for _, resourceServer := range resourceServers {
+				log.Printf("[DEBUG] ➝ %s", resourceServer.GetName())
+
+				if isTestResource(resourceServer.GetName()) {
+					result = multierror.Append(
+						result,
+						deleteResource(resourceServer.GetID(), resourceServer.GetName(), func() error {
+							return api.ResourceServer.Delete(resourceServer.GetID())
+						}),
+					)
 				}
 			}
 
-			return api.ResourceServer.Stream(fn, management.IncludeFields("id", "name"))
+			return result.ErrorOrNil()
 		},
 	})
 }
@@ -19,28 +19,33 @@ func Roles() {
 				return err
 			}
 
-			var page int
-			var result *multierror.Error
-			for {
-				roleList, err := api.Role.List(management.Page(page))
+			roles, err := listAll(func(page int) ([]*management.Role, bool, error) {
+				roleList, err := api.Role.List(
+					management.Page(page),
+					management.PerPage(100),
+				)
 				if err != nil {
-					return err
+					return nil, false, err
 				}
 
-				for _, role := range roleList.Roles {
-					log.Printf("[DEBUG] ➝ %s", role.GetName())
-					if strings.Contains(role.GetName(), "Test") {
-						result = multierror.Append(
-							result,
-							api.Role.Delete(role.GetID()),
-						)
-						log.Printf("[DEBUG] ✗ %s", role.GetName())
-					}
-				}
-				if !roleList.HasNext() {
-					break
+				return roleList.Roles, roleList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
+
+			var result *multierror.Error
+			for _, role := range roles {
+				log.Printf("[DEBUG] ➝ %s", role.GetName())
+
+				if strings.Contains(role.GetName(), "Test") {
+					result = multierror.Append(
+						result,
+						deleteResource(role.GetID(), role.GetName(), func() error {
+							return api.Role.Delete(role.GetID())
+						}),
+					)
 				}
-				page++
 			}
 
 			return result.ErrorOrNil()
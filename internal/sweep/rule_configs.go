@@ -29,9 +29,10 @@ func RuleConfigs() {
 				if strings.Contains(c.GetKey(), "test") {
 					result = multierror.Append(
 						result,
-						api.RuleConfig.Delete(c.GetKey()),
+						deleteResource(c.GetKey(), c.GetKey(), func() error {
+							return api.RuleConfig.Delete(c.GetKey())
+						}),
 					)
-					log.Printf("[DEBUG] ✗ %s", c.GetKey())
 				}
 			}
 
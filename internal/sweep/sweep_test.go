@@ -7,6 +7,7 @@ import (
 )
 
 func init() {
+	Actions()
 	Clients()
 	Connections()
 	CustomDomains()
@@ -0,0 +1,26 @@
+package sweep
+
+import (
+	"os"
+	"strings"
+)
+
+// defaultTestPrefix is the prefix with which the
+// acceptance tests name the resources they create.
+const defaultTestPrefix = "Acceptance"
+
+// testPrefix returns the prefix of the names of the resources created
+// through tests. It can be overridden with the AUTH0_TEST_PREFIX env var.
+func testPrefix() string {
+	if prefix := os.Getenv("AUTH0_TEST_PREFIX"); prefix != "" {
+		return prefix
+	}
+
+	return defaultTestPrefix
+}
+
+// isTestResource returns true if the name belongs to a resource
+// created through tests, so that it can be safely swept.
+func isTestResource(name string) bool {
+	return strings.HasPrefix(name, testPrefix())
+}
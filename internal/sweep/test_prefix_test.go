@@ -0,0 +1,52 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTestResource(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		givenPrefix    string
+		givenName      string
+		expectedResult bool
+	}{
+		{
+			name:           "it matches the default prefix",
+			givenName:      "Acceptance-Test-Connection-TestAccConnection",
+			expectedResult: true,
+		},
+		{
+			name:           "it does not match names only containing the prefix",
+			givenName:      "Production Acceptance Connection",
+			expectedResult: false,
+		},
+		{
+			name:           "it does not match names containing Test",
+			givenName:      "Test Connection",
+			expectedResult: false,
+		},
+		{
+			name:           "it matches a custom prefix",
+			givenPrefix:    "tf-test-",
+			givenName:      "tf-test-connection",
+			expectedResult: true,
+		},
+		{
+			name:           "it does not match the default prefix when a custom one is set",
+			givenPrefix:    "tf-test-",
+			givenName:      "Acceptance-Test-Connection",
+			expectedResult: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("AUTH0_TEST_PREFIX", testCase.givenPrefix)
+
+			assert.Equal(t, testCase.expectedResult, isTestResource(testCase.givenName))
+		})
+	}
+}
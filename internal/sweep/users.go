@@ -1,13 +1,18 @@
 package sweep
 
 import (
-	"log"
+	"fmt"
+	"os"
 
 	"github.com/auth0/go-auth0/management"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+// defaultTestUserEmailDomain is the email domain
+// of the users created through the acceptance tests.
+const defaultTestUserEmailDomain = "acceptance.test.com"
+
 // Users will run a test sweeper to remove all Auth0 Users created through tests.
 func Users() {
 	resource.AddTestSweepers("auth0_user", &resource.Sweeper{
@@ -18,30 +23,43 @@ func Users() {
 				return err
 			}
 
-			var page int
-			var result *multierror.Error
-			for {
+			users, err := listAll(func(page int) ([]*management.User, bool, error) {
 				userList, err := api.User.Search(
 					management.Page(page),
-					management.Query(`email.domain:"acceptance.test.com"`))
+					management.PerPage(100),
+					management.Query(fmt.Sprintf("email.domain:%q", testUserEmailDomain())),
+				)
 				if err != nil {
-					return err
+					return nil, false, err
 				}
 
-				for _, user := range userList.Users {
-					result = multierror.Append(
-						result,
-						api.User.Delete(user.GetID()),
-					)
-					log.Printf("[DEBUG] ✗ %s", user.GetName())
-				}
-				if !userList.HasNext() {
-					break
-				}
-				page++
+				return userList.Users, userList.HasNext(), nil
+			})
+			if err != nil {
+				return err
+			}
+
+			var result *multierror.Error
+			for _, user := range users {
+				result = multierror.Append(
+					result,
+					deleteResource(user.GetID(), user.GetName(), func() error {
+						return api.User.Delete(user.GetID())
+					}),
+				)
 			}
 
 			return result.ErrorOrNil()
 		},
 	})
 }
+
+// testUserEmailDomain returns the email domain of the users created through
+// tests. It can be overridden with the AUTH0_TEST_USER_EMAIL_DOMAIN env var.
+func testUserEmailDomain() string {
+	if domain := os.Getenv("AUTH0_TEST_USER_EMAIL_DOMAIN"); domain != "" {
+		return domain
+	}
+
+	return defaultTestUserEmailDomain
+}
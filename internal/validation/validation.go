@@ -3,6 +3,8 @@ package validation
 import (
 	"fmt"
 	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func IsURLWithHTTPSorEmptyString(rawURL interface{}, key string) ([]string, []error) {
@@ -38,3 +40,13 @@ func IsURLWithHTTPSorEmptyString(rawURL interface{}, key string) ([]string, []er
 
 	return nil, nil
 }
+
+// IsURLWithHTTPorHTTPSorEmptyString accepts http and https URLs, and empty
+// strings for the optional attributes that can be explicitly cleared.
+func IsURLWithHTTPorHTTPSorEmptyString(rawURL interface{}, key string) ([]string, []error) {
+	if urlString, ok := rawURL.(string); ok && urlString == "" {
+		return nil, nil
+	}
+
+	return validation.IsURLWithHTTPorHTTPS(rawURL, key)
+}
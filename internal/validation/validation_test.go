@@ -62,3 +62,47 @@ func TestIsURLWithHTTPSorEmptyString(t *testing.T) {
 		})
 	}
 }
+
+func TestIsURLWithHTTPorHTTPSorEmptyString(t *testing.T) {
+	var testCases = []struct {
+		inputURL       string
+		expectedErrors []string
+	}{
+		{
+			inputURL:       "http://example.com/foo",
+			expectedErrors: nil,
+		},
+		{
+			inputURL:       "https://example.com/foo",
+			expectedErrors: nil,
+		},
+		{
+			inputURL:       "",
+			expectedErrors: nil,
+		},
+		{
+			inputURL: "ftp://example.com",
+			expectedErrors: []string{
+				"expected \"theTestURL\" to have a url with schema of: \"http,https\", got ftp://example.com",
+			},
+		},
+		{
+			inputURL: "broken/url",
+			expectedErrors: []string{
+				"expected \"theTestURL\" to have a host, got broken/url",
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("test case #%d", i), func(t *testing.T) {
+			var errorsAsString []string
+			_, actualErrors := IsURLWithHTTPorHTTPSorEmptyString(testCase.inputURL, "theTestURL")
+			for _, actualError := range actualErrors {
+				errorsAsString = append(errorsAsString, actualError.Error())
+			}
+
+			assert.Equal(t, testCase.expectedErrors, errorsAsString)
+		})
+	}
+}